@@ -0,0 +1,190 @@
+// Package backtest replays historical matches through a rating model, predicting each match before rating it.
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/Sebsh1/openskill.go/evaluation"
+)
+
+// defaultBins is the number of bins of the reliability curves if Config.Bins is not set.
+const defaultBins = 10
+
+// Phase is the part of a backtest a match belongs to.
+type Phase int
+
+const (
+	// WarmUp matches are rated but not scored, so the ratings can settle first.
+	WarmUp Phase = iota
+	// Train matches are scored in Result.Train.
+	Train
+	// Test matches are scored in Result.Test.
+	Test
+)
+
+// Config configures a backtest. The zero value scores every match in Result.Train.
+type Config struct {
+	// WarmUpMatches is the number of matches at the start that are not scored.
+	WarmUpMatches int
+	// WarmUpUntil excludes matches before this time from scoring.
+	WarmUpUntil time.Time
+	// TestFrom scores matches at or after this time in Result.Test instead of Result.Train.
+	TestFrom time.Time
+	// Bins is the number of bins of the reliability curves, 10 by default.
+	Bins int
+	// NewRating returns the rating of players in their first match. If nil, Model.NewRating is used for models and
+	// the default rating otherwise.
+	NewRating func() openskill.Rating
+}
+
+// MatchResult is the prediction of a single match made with the ratings before it was rated.
+type MatchResult struct {
+	ID         string
+	Time       time.Time
+	Phase      Phase
+	Prediction evaluation.Prediction
+}
+
+// Result is the outcome of a backtest.
+type Result[K comparable] struct {
+	// Matches holds the prediction of every match in order.
+	Matches []MatchResult
+	// Ratings holds the ratings of every player after the last match.
+	Ratings map[K]openskill.Rating
+	// Train scores the matches after the warm-up and before Config.TestFrom.
+	Train evaluation.Report
+	// Test scores the matches at or after Config.TestFrom.
+	Test evaluation.Report
+}
+
+// Run replays the matches in chronological order. Each match is predicted with the current ratings and then rated,
+// so no match is predicted with ratings that already know its outcome. The Predictor may be nil if the Rater is a
+// Model, in which case the model predicts itself. Matches without a time are allowed anywhere in the stream.
+// Predictions are scored against the ranks the model rates each match with, so scores within the tie tolerance of a
+// Model count as draws. Scores of a plain Rater only tie if they are exactly equal. The decay of a Model is applied
+// to the ratings before the match is predicted, just as before it is rated.
+func Run[K comparable](r openskill.Rater, p openskill.Predictor, matches []openskill.PlayerMatch[K], cfg Config) (Result[K], error) {
+	model, isModel := r.(openskill.Model)
+	if p == nil {
+		if !isModel {
+			return Result[K]{}, ErrNoPredictor
+		}
+		p = model
+	}
+
+	newRating := cfg.NewRating
+	if newRating == nil {
+		prior := openskill.DefaultPlackettLuceModel()
+		if isModel {
+			prior = model
+		}
+		newRating = func() openskill.Rating { return prior.NewRating() }
+	}
+
+	bins := cfg.Bins
+	if bins == 0 {
+		bins = defaultBins
+	}
+
+	result := Result[K]{
+		Matches: make([]MatchResult, 0, len(matches)),
+		Ratings: make(map[K]openskill.Rating),
+	}
+	var train, test []evaluation.Prediction
+	var last time.Time
+
+	for i, match := range matches {
+		if !match.Time.IsZero() {
+			if match.Time.Before(last) {
+				return Result[K]{}, fmt.Errorf("%w: match %d", ErrNotChronological, i)
+			}
+			last = match.Time
+		}
+
+		if match.Outcome.Kind() == openskill.NoOutcome {
+			return Result[K]{}, fmt.Errorf("match %d: %w", i, openskill.ErrNoRanksOrScores)
+		}
+		for _, player := range match.Players() {
+			if _, ok := result.Ratings[player]; !ok {
+				result.Ratings[player] = newRating()
+			}
+		}
+
+		resolved, err := match.Resolve(result.Ratings, nil)
+		if err != nil {
+			return Result[K]{}, fmt.Errorf("match %d: %w", i, err)
+		}
+		teams := resolved.Teams
+		ranks := match.Outcome.Ranking()
+		if isModel {
+			teams = decayTeams(model, resolved)
+			ranks = model.Ranking(match.Outcome)
+		}
+		prediction, err := evaluation.Predict(p, teams, ranks)
+		if err != nil {
+			return Result[K]{}, fmt.Errorf("match %d: %w", i, err)
+		}
+
+		updated, err := openskill.RatePlayers(r, match, result.Ratings)
+		if err != nil {
+			return Result[K]{}, fmt.Errorf("match %d: %w", i, err)
+		}
+		for player, rating := range updated {
+			result.Ratings[player] = rating
+		}
+
+		phase := phaseOf(i, match.Time, cfg)
+		switch phase {
+		case Train:
+			train = append(train, prediction)
+		case Test:
+			test = append(test, prediction)
+		}
+		result.Matches = append(result.Matches, MatchResult{ID: match.ID, Time: match.Time, Phase: phase, Prediction: prediction})
+	}
+
+	var err error
+	if len(train) > 0 {
+		if result.Train, err = evaluation.Evaluate(train, bins); err != nil {
+			return Result[K]{}, err
+		}
+	}
+	if len(test) > 0 {
+		if result.Test, err = evaluation.Evaluate(test, bins); err != nil {
+			return Result[K]{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// decayTeams returns the teams of the match with the model's decay applied, so they are predicted with the same
+// ratings the model rates them with.
+func decayTeams(model openskill.Model, match openskill.Match) [][]openskill.Rating {
+	if match.Time.IsZero() || match.LastPlayed == nil {
+		return match.Teams
+	}
+
+	teams := make([][]openskill.Rating, len(match.Teams))
+	for i, team := range match.Teams {
+		teams[i] = make([]openskill.Rating, len(team))
+		for j, player := range team {
+			teams[i][j] = model.Decay(player, match.LastPlayed[i][j], match.Time)
+		}
+	}
+	return teams
+}
+
+// phaseOf returns the phase of the i-th match played at t.
+func phaseOf(i int, t time.Time, cfg Config) Phase {
+	switch {
+	case i < cfg.WarmUpMatches, !cfg.WarmUpUntil.IsZero() && t.Before(cfg.WarmUpUntil):
+		return WarmUp
+	case !cfg.TestFrom.IsZero() && !t.Before(cfg.TestFrom):
+		return Test
+	default:
+		return Train
+	}
+}
@@ -0,0 +1,130 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/Sebsh1/openskill.go/evaluation"
+	"github.com/Sebsh1/openskill.go/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// plainRater is a Rater that is not a Model.
+type plainRater struct{}
+
+func (plainRater) Rate(teams [][]openskill.Rating, ranks []int, scores []int, weights [][]float64) ([][]openskill.Rating, error) {
+	return openskill.DefaultPlackettLuceModel().Rate(teams, ranks, scores, weights)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	matches := testutil.SyntheticMatches(2000, start)
+
+	t.Run("predicts better than chance", func(t *testing.T) {
+		result, err := Run[int](openskill.DefaultThurstoneMostellerFullModel(), nil, matches, Config{WarmUpMatches: 200})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Matches, 2000)
+		assert.Equal(t, 1800, result.Train.Matches)
+		assert.Zero(t, result.Test.Matches)
+		assert.Less(t, result.Train.LogLoss, math.Log(2))
+		assert.Greater(t, result.Train.Accuracy, 0.6)
+		assert.Greater(t, result.Ratings[19].Mu, result.Ratings[0].Mu)
+	})
+
+	t.Run("predictions use ratings before the match", func(t *testing.T) {
+		result, err := Run[int](openskill.DefaultBradlyTerryFullModel(), nil, matches[:1], Config{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{0.5, 0.5}, result.Matches[0].Prediction.Probabilities)
+		assert.NotEqual(t, openskill.DefaultBradlyTerryFullModel().NewRating(), result.Ratings[matches[0].Teams[0][0]])
+	})
+
+	t.Run("predictions use decayed ratings", func(t *testing.T) {
+		m, err := openskill.NewThurstoneMostellerFullModelWithOptions(openskill.WithDecay(openskill.LinearDecay(1, 24*time.Hour)))
+		assert.NoError(t, err)
+		later := start.Add(10 * 24 * time.Hour)
+		played := []openskill.PlayerMatch[int]{
+			{Time: start, Teams: [][]int{{1}, {2}}, Outcome: openskill.Ranks(1, 2)},
+			{Time: later, Teams: [][]int{{1}, {2}}, Outcome: openskill.Ranks(1, 2), LastPlayed: map[int]time.Time{1: start, 2: start}},
+		}
+
+		first, err := Run[int](m, nil, played[:1], Config{})
+		assert.NoError(t, err)
+		result, err := Run[int](m, nil, played, Config{})
+		assert.NoError(t, err)
+
+		stale, err := evaluation.Predict(m, [][]openskill.Rating{{first.Ratings[1]}, {first.Ratings[2]}}, []int{1, 2})
+		assert.NoError(t, err)
+		decayed, err := evaluation.Predict(m, [][]openskill.Rating{
+			{m.Decay(first.Ratings[1], start, later)},
+			{m.Decay(first.Ratings[2], start, later)},
+		}, []int{1, 2})
+		assert.NoError(t, err)
+
+		assert.Equal(t, decayed, result.Matches[1].Prediction)
+		assert.NotEqual(t, stale.Probabilities, decayed.Probabilities)
+	})
+
+	t.Run("phases", func(t *testing.T) {
+		cfg := Config{
+			WarmUpMatches: 10,
+			WarmUpUntil:   start.Add(100 * time.Hour),
+			TestFrom:      start.Add(1500 * time.Hour),
+			Bins:          5,
+		}
+
+		result, err := Run[int](openskill.DefaultBradlyTerryFullModel(), nil, matches, cfg)
+
+		assert.NoError(t, err)
+		assert.Equal(t, WarmUp, result.Matches[99].Phase)
+		assert.Equal(t, Train, result.Matches[100].Phase)
+		assert.Equal(t, Train, result.Matches[1499].Phase)
+		assert.Equal(t, Test, result.Matches[1500].Phase)
+		assert.Equal(t, 1400, result.Train.Matches)
+		assert.Equal(t, 500, result.Test.Matches)
+		assert.Len(t, result.Test.Reliability, 5)
+	})
+
+	t.Run("plain rater needs a predictor", func(t *testing.T) {
+		_, err := Run[int](plainRater{}, nil, matches, Config{})
+		assert.ErrorIs(t, err, ErrNoPredictor)
+
+		result, err := Run[int](plainRater{}, openskill.DefaultPredictor(), matches[:10], Config{
+			NewRating: func() openskill.Rating { return openskill.Rating{Mu: 30, Sigma: 5} },
+		})
+		assert.NoError(t, err)
+		assert.Len(t, result.Matches, 10)
+	})
+
+	t.Run("ranks use the tie tolerance of the model", func(t *testing.T) {
+		m, err := openskill.NewThurstoneMostellerFullModelWithOptions(openskill.WithAbsoluteTieTolerance(0.5))
+		assert.NoError(t, err)
+		nearTie := []openskill.PlayerMatch[int]{{Teams: [][]int{{1}, {2}, {3}}, Outcome: openskill.Scores(10, 10.2, 3)}}
+
+		result, err := Run[int](m, nil, nearTie, Config{})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 1, 3}, result.Matches[0].Prediction.Ranks)
+
+		result, err = Run[int](plainRater{}, openskill.DefaultPredictor(), nearTie, Config{})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2, 1, 3}, result.Matches[0].Prediction.Ranks)
+	})
+
+	t.Run("invalid matches", func(t *testing.T) {
+		m := openskill.DefaultPlackettLuceModel()
+
+		_, err := Run[int](m, nil, []openskill.PlayerMatch[int]{matches[1], matches[0]}, Config{})
+		assert.ErrorIs(t, err, ErrNotChronological)
+
+		_, err = Run[int](m, nil, []openskill.PlayerMatch[int]{{Teams: [][]int{{1}, {2}}}}, Config{})
+		assert.ErrorIs(t, err, openskill.ErrNoRanksOrScores)
+
+		_, err = Run[int](m, nil, []openskill.PlayerMatch[int]{{Teams: [][]int{{1}, {1}}, Outcome: openskill.Ranks(1, 2)}}, Config{})
+		assert.ErrorIs(t, err, openskill.ErrDuplicatePlayer)
+	})
+}
@@ -0,0 +1,8 @@
+package backtest
+
+import "fmt"
+
+var (
+	ErrNoPredictor      = fmt.Errorf("a predictor must be provided when the rater is not a model")
+	ErrNotChronological = fmt.Errorf("matches must be in chronological order")
+)
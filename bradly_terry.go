@@ -3,46 +3,105 @@ package openskill
 import (
 	"math"
 	"sort"
+	"time"
 )
 
 type BradlyTerryFullModel struct {
+	BradlyTerryPredictor
 	mu         float64
 	sigma      float64
-	beta       float64
-	kappa      float64
 	tau        float64
+	margin     float64
+	tolerance  tieTolerance
+	weighting  WeightStrategy
+	decay      DecayCurve
 	limitSigma bool
-	balance    bool
 }
 
-func DefaultBradlyTerryFullModel() Rater {
-	return BradlyTerryFullModel{
-		mu:         25.0,
-		sigma:      25.0 / 3.0,
-		beta:       25.0 / 6.0,
-		kappa:      0.0001,
-		tau:        25.0 / 300.0,
-		limitSigma: false,
-		balance:    false,
+// DefaultBradlyTerryFullModel returns a new BradlyTerryFullModel with sensible default parameter values.
+func DefaultBradlyTerryFullModel() Model {
+	return newBradlyTerryFullModel(defaultOptions())
+}
+
+// NewBradlyTerryFullModel returns a new BradlyTerryFullModel with custom parameter values.
+// The parameters are not validated, use NewBradlyTerryFullModelWithOptions to reject invalid values.
+func NewBradlyTerryFullModel(mu, sigma, beta, kappa, tau float64, limitSigma, balance bool) Model {
+	return newBradlyTerryFullModel(applyOptions(
+		WithMu(mu),
+		WithSigma(sigma),
+		WithBeta(beta),
+		WithKappa(kappa),
+		WithTau(tau),
+		WithLimitSigma(limitSigma),
+		WithBalance(balance),
+	))
+}
+
+// NewBradlyTerryFullModelWithOptions returns a new BradlyTerryFullModel configured by the options on top of the default parameter values.
+// An error wrapping ErrInvalidParameter is returned if any of the resulting parameters are invalid.
+// Epsilon is ignored by this model.
+func NewBradlyTerryFullModelWithOptions(opts ...Option) (Model, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
 	}
+
+	return newBradlyTerryFullModel(o), nil
 }
 
-func NewBradlyTerryFullModel(mu, sigma, beta, kappa, tau float64, limitSigma, balance bool) Rater {
+func newBradlyTerryFullModel(o options) BradlyTerryFullModel {
 	return BradlyTerryFullModel{
-		mu:         mu,
-		sigma:      sigma,
-		beta:       beta,
-		kappa:      kappa,
-		tau:        tau,
-		limitSigma: limitSigma,
-		balance:    balance,
+		BradlyTerryPredictor: NewBradlyTerryPredictor(o.beta, o.kappa, o.balance),
+		mu:                   o.mu,
+		sigma:                o.sigma,
+		tau:                  o.tau,
+		margin:               o.margin,
+		tolerance:            o.tolerance,
+		weighting:            o.weighting,
+		decay:                o.decay,
+		limitSigma:           o.limitSigma,
 	}
 }
 
+// NewRating returns a new rating from the model's mu and sigma unless overridden.
+func (b BradlyTerryFullModel) NewRating(opts ...RatingOption) Rating {
+	return newRating(b.mu, b.sigma, opts)
+}
+
+// Decay returns the rating with its sigma inflated by the model's decay curve for the time between lastPlayed and now,
+// capped at the model's prior sigma. The rating is returned unchanged if the model has no decay curve.
+func (b BradlyTerryFullModel) Decay(rating Rating, lastPlayed, now time.Time) Rating {
+	return InflateSigma(rating, lastPlayed, now, b.decay, b.sigma)
+}
+
+// Ranking returns the ranks the model rates the outcome with, where scores within the model's tie tolerance tie.
+func (b BradlyTerryFullModel) Ranking(outcome Outcome) []int {
+	return outcome.ranking(b.tolerance)
+}
+
+// Rate updates the ratings of the teams based on either their ranks or scores, optionally weighted per player.
 func (b BradlyTerryFullModel) Rate(teams [][]Rating, ranks, scores []int, weights [][]float64) ([][]Rating, error) {
+	return b.rate(teams, ranks, intsToFloats(scores), weights, nil)
+}
+
+// RateMatch updates the ratings of the players in the match based on its outcome.
+// If the model has a decay curve, the sigma of each player is first inflated for the time since they last played.
+func (b BradlyTerryFullModel) RateMatch(match Match) ([][]Rating, error) {
+	teams, err := decayTeams(match, b.decay, b.sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.rate(teams, match.Outcome.Ranks(), match.Outcome.Scores(), match.Weights, match.PartialPlay)
+}
+
+func (b BradlyTerryFullModel) rate(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) ([][]Rating, error) {
 	if err := checkRateParameters(teams, ranks, scores, weights); err != nil {
 		return nil, err
 	}
+	if err := checkPartialPlay(teams, partialPlay); err != nil {
+		return nil, err
+	}
 
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
@@ -67,31 +126,39 @@ func (b BradlyTerryFullModel) Rate(teams [][]Rating, ranks, scores []int, weight
 	}
 
 	if scores != nil {
-		ranks = make([]int, len(scores))
-		for i, score := range scores {
-			ranks[i] = -score
-		}
+		ranks = scoresToRanks(scores, b.tolerance)
 	}
 
-	for i := range weights {
-		weights[i] = normalize(weights[i], 1, 2)
+	weights, err := normalizeWeights(weights, b.weighting)
+	if err != nil {
+		return nil, err
 	}
 
 	var tenet []int
+	sortedPartialPlay := partialPlay
 	var orderedTeams [][]Rating
 
 	if ranks != nil {
 		orderedTeams, tenet = unwind(ranks, teamsCopy)
 		teamsCopy = orderedTeams
+		if scores != nil {
+			scores, _ = unwind(ranks, scores)
+		}
+		if weights != nil {
+			weights, _ = unwind(ranks, weights)
+		}
+		if partialPlay != nil {
+			sortedPartialPlay, _ = unwind(ranks, partialPlay)
+		}
 		sort.Ints(ranks)
 	}
 
 	var result [][]Rating
 	if ranks != nil && tenet != nil {
-		result = b.compute(teamsCopy, ranks, weights)
+		result = b.compute(teamsCopy, ranks, scores, weights, sortedPartialPlay)
 		result, _ = unwind(tenet, result)
 	} else {
-		result = b.compute(teamsCopy, nil, weights)
+		result = b.compute(teamsCopy, nil, nil, weights, sortedPartialPlay)
 	}
 
 	finalResult := make([][]Rating, len(result))
@@ -102,6 +169,10 @@ func (b BradlyTerryFullModel) Rate(teams [][]Rating, ranks, scores []int, weight
 		}
 	}
 
+	if partialPlay != nil {
+		finalResult = applyPartialPlay(teams, finalResult, partialPlay)
+	}
+
 	if b.limitSigma {
 		for teamIndex, team := range finalResult {
 			for playerIndex, player := range team {
@@ -112,13 +183,13 @@ func (b BradlyTerryFullModel) Rate(teams [][]Rating, ranks, scores []int, weight
 	return finalResult, nil
 }
 
-func (b BradlyTerryFullModel) compute(teams [][]Rating, ranks []int, weights [][]float64) [][]Rating {
+func (b BradlyTerryFullModel) compute(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) [][]Rating {
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
 		originalTeams[i] = make([]Rating, len(teams[i]))
 		copy(originalTeams[i], teams[i])
 	}
-	teamRatings := calculateTeamRatings(teams, ranks, b.balance, b.kappa)
+	teamRatings := calculatePartialTeamRatings(teams, ranks, partialPlay, b.balance, b.kappa)
 
 	result := make([][]Rating, len(teamRatings))
 	for i, t1 := range teamRatings {
@@ -141,7 +212,12 @@ func (b BradlyTerryFullModel) compute(teams [][]Rating, ranks []int, weights [][
 				s = 0.5
 			}
 
-			omega += sigmaSquaredToCiq * (s - piq)
+			margin := 1.0
+			if scores != nil {
+				margin = marginFactor(scores[i]-scores[q], b.margin)
+			}
+
+			omega += sigmaSquaredToCiq * (s - piq) * margin
 			gammaValue := math.Sqrt(t1.SigmaSquared / cIq)
 
 			delta += ((gammaValue * sigmaSquaredToCiq) / cIq) * piq * (1 - piq)
@@ -173,43 +249,101 @@ func (b BradlyTerryFullModel) compute(teams [][]Rating, ranks []int, weights [][
 }
 
 type BradlyTerryPartialModel struct {
+	BradlyTerryPredictor
 	mu         float64
 	sigma      float64
-	beta       float64
-	kappa      float64
 	tau        float64
+	margin     float64
+	tolerance  tieTolerance
+	weighting  WeightStrategy
+	decay      DecayCurve
 	limitSigma bool
-	balance    bool
 }
 
-func DefaultBradlyTerryPartialModel() Rater {
-	return BradlyTerryPartialModel{
-		mu:         25.0,
-		sigma:      25.0 / 3.0,
-		beta:       25.0 / 6.0,
-		kappa:      0.0001,
-		tau:        25.0 / 300.0,
-		limitSigma: false,
-		balance:    false,
+// DefaultBradlyTerryPartialModel returns a new BradlyTerryPartialModel with sensible default parameter values.
+func DefaultBradlyTerryPartialModel() Model {
+	return newBradlyTerryPartialModel(defaultOptions())
+}
+
+// NewBradlyTerryPartialModell returns a new BradlyTerryPartialModel with custom parameter values.
+// The parameters are not validated, use NewBradlyTerryPartialModelWithOptions to reject invalid values.
+func NewBradlyTerryPartialModell(mu, sigma, beta, kappa, tau float64, limitSigma, balance bool) Model {
+	return newBradlyTerryPartialModel(applyOptions(
+		WithMu(mu),
+		WithSigma(sigma),
+		WithBeta(beta),
+		WithKappa(kappa),
+		WithTau(tau),
+		WithLimitSigma(limitSigma),
+		WithBalance(balance),
+	))
+}
+
+// NewBradlyTerryPartialModelWithOptions returns a new BradlyTerryPartialModel configured by the options on top of the default parameter values.
+// An error wrapping ErrInvalidParameter is returned if any of the resulting parameters are invalid.
+// Epsilon is ignored by this model.
+func NewBradlyTerryPartialModelWithOptions(opts ...Option) (Model, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
 	}
+
+	return newBradlyTerryPartialModel(o), nil
 }
 
-func NewBradlyTerryPartialModell(mu, sigma, beta, kappa, tau float64, limitSigma, balance bool) Rater {
+func newBradlyTerryPartialModel(o options) BradlyTerryPartialModel {
 	return BradlyTerryPartialModel{
-		mu:         mu,
-		sigma:      sigma,
-		beta:       beta,
-		kappa:      kappa,
-		tau:        tau,
-		limitSigma: limitSigma,
-		balance:    balance,
+		BradlyTerryPredictor: NewBradlyTerryPredictor(o.beta, o.kappa, o.balance),
+		mu:                   o.mu,
+		sigma:                o.sigma,
+		tau:                  o.tau,
+		margin:               o.margin,
+		tolerance:            o.tolerance,
+		weighting:            o.weighting,
+		decay:                o.decay,
+		limitSigma:           o.limitSigma,
 	}
 }
 
+// NewRating returns a new rating from the model's mu and sigma unless overridden.
+func (b BradlyTerryPartialModel) NewRating(opts ...RatingOption) Rating {
+	return newRating(b.mu, b.sigma, opts)
+}
+
+// Decay returns the rating with its sigma inflated by the model's decay curve for the time between lastPlayed and now,
+// capped at the model's prior sigma. The rating is returned unchanged if the model has no decay curve.
+func (b BradlyTerryPartialModel) Decay(rating Rating, lastPlayed, now time.Time) Rating {
+	return InflateSigma(rating, lastPlayed, now, b.decay, b.sigma)
+}
+
+// Ranking returns the ranks the model rates the outcome with, where scores within the model's tie tolerance tie.
+func (b BradlyTerryPartialModel) Ranking(outcome Outcome) []int {
+	return outcome.ranking(b.tolerance)
+}
+
+// Rate updates the ratings of the teams based on either their ranks or scores, optionally weighted per player.
 func (b BradlyTerryPartialModel) Rate(teams [][]Rating, ranks, scores []int, weights [][]float64) ([][]Rating, error) {
+	return b.rate(teams, ranks, intsToFloats(scores), weights, nil)
+}
+
+// RateMatch updates the ratings of the players in the match based on its outcome.
+// If the model has a decay curve, the sigma of each player is first inflated for the time since they last played.
+func (b BradlyTerryPartialModel) RateMatch(match Match) ([][]Rating, error) {
+	teams, err := decayTeams(match, b.decay, b.sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.rate(teams, match.Outcome.Ranks(), match.Outcome.Scores(), match.Weights, match.PartialPlay)
+}
+
+func (b BradlyTerryPartialModel) rate(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) ([][]Rating, error) {
 	if err := checkRateParameters(teams, ranks, scores, weights); err != nil {
 		return nil, err
 	}
+	if err := checkPartialPlay(teams, partialPlay); err != nil {
+		return nil, err
+	}
 
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
@@ -234,29 +368,36 @@ func (b BradlyTerryPartialModel) Rate(teams [][]Rating, ranks, scores []int, wei
 	}
 
 	if scores != nil {
-		ranks = make([]int, len(scores))
-		for i, score := range scores {
-			ranks[i] = int(-score)
-		}
+		ranks = scoresToRanks(scores, b.tolerance)
 	}
 
-	for i := range weights {
-		weights[i] = normalize(weights[i], 1, 2)
+	weights, err := normalizeWeights(weights, b.weighting)
+	if err != nil {
+		return nil, err
 	}
 
-	tenet := make([]int, len(ranks))
-	for i := range ranks {
-		tenet[i] = i
-	}
+	var tenet []int
+	sortedPartialPlay := partialPlay
+	sortedTeams := teamsCopy
 	if ranks != nil {
+		sortedTeams, tenet = unwind(ranks, teamsCopy)
+		if scores != nil {
+			scores, _ = unwind(ranks, scores)
+		}
+		if weights != nil {
+			weights, _ = unwind(ranks, weights)
+		}
+		if partialPlay != nil {
+			sortedPartialPlay, _ = unwind(ranks, partialPlay)
+		}
 		sort.Ints(ranks)
 	}
 
-	processedResult := make([][]Rating, len(teams))
+	processedResult := make([][]Rating, 0, len(teams))
 	var result [][]Rating
 
 	if ranks != nil {
-		result = b.compute(teamsCopy, ranks, weights)
+		result = b.compute(sortedTeams, ranks, scores, weights, sortedPartialPlay)
 		unwoundResult, _ := unwind(tenet, result)
 		for _, item := range unwoundResult {
 			team := make([]Rating, len(item))
@@ -264,7 +405,7 @@ func (b BradlyTerryPartialModel) Rate(teams [][]Rating, ranks, scores []int, wei
 			processedResult = append(processedResult, team)
 		}
 	} else {
-		result = b.compute(teamsCopy, nil, weights)
+		result = b.compute(sortedTeams, nil, nil, weights, sortedPartialPlay)
 		for _, item := range result {
 			team := make([]Rating, len(item))
 			copy(team, item)
@@ -274,29 +415,33 @@ func (b BradlyTerryPartialModel) Rate(teams [][]Rating, ranks, scores []int, wei
 
 	finalResult := processedResult
 
+	if partialPlay != nil {
+		finalResult = applyPartialPlay(teams, finalResult, partialPlay)
+	}
+
 	if b.limitSigma {
-		finalResult = make([][]Rating, len(processedResult))
-		for teamIndex, team := range processedResult {
-			finalTeam := make([]Rating, len(team))
+		for teamIndex, team := range finalResult {
 			for playerIndex, player := range team {
-				player.Sigma = math.Min(player.Sigma, originalTeams[teamIndex][playerIndex].Sigma)
-				finalTeam[playerIndex] = player
+				finalResult[teamIndex][playerIndex].Sigma = math.Min(player.Sigma, originalTeams[teamIndex][playerIndex].Sigma)
 			}
-			finalResult[teamIndex] = finalTeam
 		}
 	}
 
 	return finalResult, nil
 }
 
-func (b BradlyTerryPartialModel) compute(teams [][]Rating, ranks []int, weights [][]float64) [][]Rating {
+func (b BradlyTerryPartialModel) compute(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) [][]Rating {
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
 		originalTeams[i] = make([]Rating, len(teams[i]))
 		copy(originalTeams[i], teams[i])
 	}
-	teamRatings := calculateTeamRatings(teams, ranks, b.balance, b.kappa)
+	teamRatings := calculatePartialTeamRatings(teams, ranks, partialPlay, b.balance, b.kappa)
 	adjacentTeams := ladderPairs(teamRatings)
+	var adjacentScores [][]float64
+	if scores != nil {
+		adjacentScores = ladderPairs(scores)
+	}
 
 	result := make([][]Rating, len(teamRatings))
 	for i, t1 := range teamRatings {
@@ -319,7 +464,12 @@ func (b BradlyTerryPartialModel) compute(teams [][]Rating, ranks []int, weights
 				s = 0.5
 			}
 
-			omega += sigmaSquaredToCiq * (s - pIq)
+			margin := 1.0
+			if adjacentScores != nil {
+				margin = marginFactor(scores[i]-adjacentScores[i][q], b.margin)
+			}
+
+			omega += sigmaSquaredToCiq * (s - pIq) * margin
 			gammaValue := math.Sqrt(t1.SigmaSquared / cIq)
 
 			delta += ((gammaValue * sigmaSquaredToCiq) / cIq) * pIq * (1 - pIq)
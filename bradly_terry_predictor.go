@@ -0,0 +1,49 @@
+package openskill
+
+import "math"
+
+// BradlyTerryPredictor predicts matches with the logistic likelihood that the Bradley-Terry models rate with, where a
+// team beats another with probability 1 / (1 + exp(-(mu_a - mu_b) / c)).
+// It falls back to the default predictor for draws, match quality and rank distributions.
+type BradlyTerryPredictor struct {
+	predictor
+}
+
+// DefaultBradlyTerryPredictor returns a new BradlyTerryPredictor with sensible default parameter values.
+func DefaultBradlyTerryPredictor() BradlyTerryPredictor {
+	return NewBradlyTerryPredictor(25.0/6.0, 0.0001, false)
+}
+
+// NewBradlyTerryPredictor returns a new BradlyTerryPredictor with custom parameter values.
+func NewBradlyTerryPredictor(beta, kappa float64, balance bool) BradlyTerryPredictor {
+	return BradlyTerryPredictor{
+		predictor: predictor{
+			beta:    beta,
+			kappa:   kappa,
+			balance: balance,
+		},
+	}
+}
+
+// ChanceOfWinning returns the probability of each team winning a match as a number between 0 and 1.
+// With more than two teams it is the average chance of beating each other team, normalized to sum to 1.
+func (b BradlyTerryPredictor) ChanceOfWinning(teams [][]Rating) ([]float64, error) {
+	if err := checkTeams(teams); err != nil {
+		return nil, err
+	}
+
+	teamRatings := calculateTeamRatings(teams, nil, b.balance, b.kappa)
+	return pairwiseChanceOfWinning(teamRatings, b.beta, func(a, other teamRating, c float64) float64 {
+		return 1 / (1 + math.Exp((other.Mu-a.Mu)/c))
+	}), nil
+}
+
+// ChanceOfRanks returns the most likely ranking of the teams and the probability of each team winning.
+func (b BradlyTerryPredictor) ChanceOfRanks(teams [][]Rating) ([]int, []float64, error) {
+	probabilities, err := b.ChanceOfWinning(teams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ranksFromProbabilities(probabilities), probabilities, nil
+}
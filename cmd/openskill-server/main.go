@@ -0,0 +1,81 @@
+// Command openskill-server serves ratings and predictions over HTTP with the JSON API of package server.
+//
+// Usage:
+//
+//	openskill-server [-addr :8080] [-model plackett-luce] [-store ratings.jsonl]
+//
+// Ratings are kept in memory unless -store names a file, which is replayed on start and appended to after every
+// rated match.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/Sebsh1/openskill.go/server"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	addr := flag.String("addr", ":8080", "the address to listen on")
+	modelName := flag.String("model", "plackett-luce", "the model, one of "+strings.Join(openskill.ModelNames(), ", "))
+	storePath := flag.String("store", "", "the file to keep ratings in; ratings are kept in memory if not set")
+	flag.Parse()
+
+	model, err := openskill.ModelByName(*modelName)
+	if err != nil {
+		return err
+	}
+
+	var store openskill.RatingStore[string] = openskill.NewMemoryStore[string]()
+	if *storePath != "" {
+		fileStore, err := openskill.OpenFileStore[string](*storePath)
+		if err != nil {
+			return err
+		}
+		defer fileStore.Close()
+		store = fileStore
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.NewHandler(model, store),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s with the %s model", *addr, *modelName)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	openskill "github.com/Sebsh1/openskill.go"
+)
+
+// matchRecord is a match as read from a JSON lines file, e.g.
+//
+//	{"id": "m1", "teams": [["alice", "bob"], ["carol"]], "ranks": [1, 2]}
+type matchRecord struct {
+	ID      string      `json:"id"`
+	Time    time.Time   `json:"time"`
+	Teams   [][]string  `json:"teams"`
+	Ranks   []int       `json:"ranks"`
+	Scores  []float64   `json:"scores"`
+	Weights [][]float64 `json:"weights"`
+}
+
+// playerMatch converts the record to a match. A record without ranks and scores has no outcome, which is fine for
+// predictions but rejected when rating.
+func (r matchRecord) playerMatch() (openskill.PlayerMatch[string], error) {
+	match := openskill.PlayerMatch[string]{
+		ID:      r.ID,
+		Time:    r.Time,
+		Teams:   r.Teams,
+		Weights: r.Weights,
+	}
+
+	switch {
+	case r.Ranks != nil && r.Scores != nil:
+		return match, openskill.ErrRanksAndScores
+	case r.Ranks != nil:
+		match.Outcome = openskill.Ranks(r.Ranks...)
+	case r.Scores != nil:
+		match.Outcome = openskill.Scores(r.Scores...)
+	}
+	return match, nil
+}
+
+// readMatches reads matches in the given format, "csv" or "jsonl".
+func readMatches(r io.Reader, format string) ([]openskill.PlayerMatch[string], error) {
+	var records []matchRecord
+	var err error
+	switch format {
+	case "jsonl":
+		records, err = readJSONLines(r)
+	case "csv":
+		records, err = readCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected csv or jsonl", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]openskill.PlayerMatch[string], len(records))
+	for i, record := range records {
+		if matches[i], err = record.playerMatch(); err != nil {
+			return nil, fmt.Errorf("match %s: %w", matchName(record.ID, i), err)
+		}
+	}
+	return matches, nil
+}
+
+// readJSONLines reads one match per line, skipping empty lines.
+func readJSONLines(r io.Reader) ([]matchRecord, error) {
+	var records []matchRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var record matchRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// readCSV reads a CSV file with a header and one row per player. The columns match, team and player are required;
+// rank or score, weight and time are optional. The rank, score and time of a team or match are taken from its first
+// row. Matches and teams are ordered by their first appearance, e.g.
+//
+//	match,team,player,rank
+//	m1,red,alice,1
+//	m1,red,bob,1
+//	m1,blue,carol,2
+func readCSV(r io.Reader) ([]matchRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"match", "team", "player"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+	_, hasRank := columns["rank"]
+	_, hasScore := columns["score"]
+	_, hasWeight := columns["weight"]
+	if hasRank && hasScore {
+		return nil, fmt.Errorf("columns rank and score cannot be used together")
+	}
+
+	var records []matchRecord
+	matchIndex := make(map[string]int)
+	teamIndex := make(map[[2]string]int)
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		id, team := field("match"), field("team")
+		m, ok := matchIndex[id]
+		if !ok {
+			m = len(records)
+			matchIndex[id] = m
+			record := matchRecord{ID: id}
+			if value := field("time"); value != "" {
+				if record.Time, err = time.Parse(time.RFC3339, value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+			}
+			records = append(records, record)
+		}
+		record := &records[m]
+
+		t, ok := teamIndex[[2]string{id, team}]
+		if !ok {
+			t = len(record.Teams)
+			teamIndex[[2]string{id, team}] = t
+			record.Teams = append(record.Teams, nil)
+			if hasWeight {
+				record.Weights = append(record.Weights, nil)
+			}
+			switch {
+			case hasRank:
+				rank, err := strconv.Atoi(field("rank"))
+				if err != nil {
+					return nil, fmt.Errorf("line %d: rank: %w", line, err)
+				}
+				record.Ranks = append(record.Ranks, rank)
+			case hasScore:
+				score, err := strconv.ParseFloat(field("score"), 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: score: %w", line, err)
+				}
+				record.Scores = append(record.Scores, score)
+			}
+		}
+
+		record.Teams[t] = append(record.Teams[t], field("player"))
+		if hasWeight {
+			weight := 1.0
+			if value := field("weight"); value != "" {
+				if weight, err = strconv.ParseFloat(value, 64); err != nil {
+					return nil, fmt.Errorf("line %d: weight: %w", line, err)
+				}
+			}
+			record.Weights[t] = append(record.Weights[t], weight)
+		}
+	}
+}
+
+// matchName identifies a match in messages by its ID, or by its position if it has none.
+func matchName(id string, i int) string {
+	if id != "" {
+		return strconv.Quote(id)
+	}
+	return "#" + strconv.Itoa(i+1)
+}
+
+// writeCSV writes rows as CSV.
+func writeCSV(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
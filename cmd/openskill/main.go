@@ -0,0 +1,312 @@
+// Command openskill rates matches, predicts their outcomes and prints leaderboards without writing any Go.
+//
+// Usage:
+//
+//	openskill rate [flags] matches.csv
+//	openskill predict [flags] matches.jsonl
+//	openskill leaderboard [flags]
+//
+// Matches are read from CSV files with one row per player, or from JSON lines files with one match per line. The
+// ratings of all players are kept in a state file, ratings.json by default, which rate updates and predict and
+// leaderboard read. Run a subcommand with -h to list its flags.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	openskill "github.com/Sebsh1/openskill.go"
+)
+
+const usage = `usage: openskill <command> [flags] [file]
+
+commands:
+  rate         rate the matches in a file and update the state file
+  predict      predict the matches in a file with the ratings in the state file
+  leaderboard  print the players in the state file sorted by ordinal
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "openskill:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run runs the subcommand named by the first argument.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return flag.ErrHelp
+	}
+
+	switch args[0] {
+	case "rate":
+		return rate(args[1:], stdin, stderr)
+	case "predict":
+		return predict(args[1:], stdin, stdout, stderr)
+	case "leaderboard":
+		return leaderboard(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stderr, usage)
+		return flag.ErrHelp
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// modelFlags are the flags that select and configure the model.
+type modelFlags struct {
+	flags  *flag.FlagSet
+	name   string
+	values map[string]*float64
+}
+
+func addModelFlags(flags *flag.FlagSet) *modelFlags {
+	m := &modelFlags{flags: flags, values: make(map[string]*float64)}
+	flags.StringVar(&m.name, "model", "plackett-luce", "the model, one of "+strings.Join(openskill.ModelNames(), ", "))
+	for _, name := range []string{"mu", "sigma", "beta", "kappa", "tau", "epsilon"} {
+		m.values[name] = flags.Float64(name, 0, "the "+name+" of the model, the model default if not set")
+	}
+	return m
+}
+
+// model builds the selected model with the parameters that were set on the command line.
+func (m *modelFlags) model() (openskill.Model, error) {
+	options := map[string]func(float64) openskill.Option{
+		"mu":      openskill.WithMu,
+		"sigma":   openskill.WithSigma,
+		"beta":    openskill.WithBeta,
+		"kappa":   openskill.WithKappa,
+		"tau":     openskill.WithTau,
+		"epsilon": openskill.WithEpsilon,
+	}
+	var opts []openskill.Option
+	m.flags.Visit(func(f *flag.Flag) {
+		if option, ok := options[f.Name]; ok {
+			opts = append(opts, option(*m.values[f.Name]))
+		}
+	})
+	return openskill.ModelByName(m.name, opts...)
+}
+
+// inputFlags are the flags that select the match file and its format.
+type inputFlags struct {
+	format string
+}
+
+func addInputFlags(flags *flag.FlagSet) *inputFlags {
+	i := &inputFlags{}
+	flags.StringVar(&i.format, "format", "", "the format of the match file, csv or jsonl; guessed from the file extension if not set")
+	return i
+}
+
+// read reads the matches in the file named by the single remaining argument, or from stdin if it is "-".
+func (i *inputFlags) read(args []string, stdin io.Reader) ([]openskill.PlayerMatch[string], error) {
+	if len(args) != 1 {
+		return nil, errors.New("expected a single match file, or - for stdin")
+	}
+
+	format := i.format
+	if format == "" {
+		format = "jsonl"
+		if strings.EqualFold(filepath.Ext(args[0]), ".csv") {
+			format = "csv"
+		}
+	}
+
+	if args[0] == "-" {
+		return readMatches(stdin, format)
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readMatches(f, format)
+}
+
+// rate rates the matches in order and saves the new ratings. The state file is left untouched if any match fails.
+func rate(args []string, stdin io.Reader, stderr io.Writer) error {
+	flags := flag.NewFlagSet("rate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	statePath := flags.String("state", "ratings.json", "the ratings state file")
+	modelFlags := addModelFlags(flags)
+	inputFlags := addInputFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	model, err := modelFlags.model()
+	if err != nil {
+		return err
+	}
+	matches, err := inputFlags.read(flags.Args(), stdin)
+	if err != nil {
+		return err
+	}
+	s, err := loadState(*statePath)
+	if err != nil {
+		return err
+	}
+
+	ratings := s.ratings()
+	for i, match := range matches {
+		if match.Outcome.Kind() == openskill.NoOutcome {
+			return fmt.Errorf("match %s: %w", matchName(match.ID, i), openskill.ErrNoRanksOrScores)
+		}
+		updated, err := openskill.RatePlayers(model, match, ratings)
+		if err != nil {
+			return fmt.Errorf("match %s: %w", matchName(match.ID, i), err)
+		}
+		for player, rating := range updated {
+			ratings[player] = rating
+			p := s.Players[player]
+			s.Players[player] = playerState{Mu: rating.Mu, Sigma: rating.Sigma, Games: p.Games + 1}
+		}
+	}
+
+	if err := s.save(*statePath); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "rated %d matches, %d players in %s\n", len(matches), len(s.Players), *statePath)
+	return nil
+}
+
+// predict writes a CSV row per team with its chance of winning and its predicted rank. Players missing from the
+// state file get the rating of a new player.
+func predict(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("predict", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	statePath := flags.String("state", "ratings.json", "the ratings state file")
+	modelFlags := addModelFlags(flags)
+	inputFlags := addInputFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	model, err := modelFlags.model()
+	if err != nil {
+		return err
+	}
+	matches, err := inputFlags.read(flags.Args(), stdin)
+	if err != nil {
+		return err
+	}
+	s, err := loadState(*statePath)
+	if err != nil {
+		return err
+	}
+
+	ratings := s.ratings()
+	newRating := func() openskill.Rating {
+		return model.NewRating()
+	}
+
+	rows := [][]string{{"match", "team", "players", "win_probability", "draw_probability", "predicted_rank"}}
+	for i, match := range matches {
+		resolved, err := match.Resolve(ratings, newRating)
+		if err != nil {
+			return fmt.Errorf("match %s: %w", matchName(match.ID, i), err)
+		}
+		ranks, probabilities, err := model.ChanceOfRanks(resolved.Teams)
+		if err != nil {
+			return fmt.Errorf("match %s: %w", matchName(match.ID, i), err)
+		}
+		draw, err := model.ChanceOfDraw(resolved.Teams)
+		if err != nil {
+			return fmt.Errorf("match %s: %w", matchName(match.ID, i), err)
+		}
+
+		id := match.ID
+		if id == "" {
+			id = strconv.Itoa(i + 1)
+		}
+		for t, team := range match.Teams {
+			rows = append(rows, []string{
+				id,
+				strconv.Itoa(t + 1),
+				strings.Join(team, ";"),
+				strconv.FormatFloat(probabilities[t], 'f', 6, 64),
+				strconv.FormatFloat(draw, 'f', 6, 64),
+				strconv.Itoa(ranks[t]),
+			})
+		}
+	}
+
+	return writeCSV(stdout, rows)
+}
+
+// leaderboard prints the players in the state file, best first.
+func leaderboard(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	statePath := flags.String("state", "ratings.json", "the ratings state file")
+	top := flags.Int("top", 0, "the number of players to print, all players if 0")
+	minGames := flags.Int("min-games", 0, "hide players who have played fewer games")
+	format := flags.String("format", "text", "the output format, text or csv")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+	if *format != "text" && *format != "csv" {
+		return fmt.Errorf("unknown format %q, expected text or csv", *format)
+	}
+
+	s, err := loadState(*statePath)
+	if err != nil {
+		return err
+	}
+
+	// Players are added in name order so players sharing a rank are listed the same way every run.
+	players := make([]string, 0, len(s.Players))
+	for player := range s.Players {
+		players = append(players, player)
+	}
+	sort.Strings(players)
+
+	board := openskill.NewLeaderboard[string](openskill.WithMinGames(*minGames))
+	for _, player := range players {
+		p := s.Players[player]
+		board.Set(player, openskill.Rating{Mu: p.Mu, Sigma: p.Sigma}, p.Games)
+	}
+
+	limit := *top
+	if limit <= 0 {
+		limit = board.Len()
+	}
+
+	rows := [][]string{{"rank", "player", "ordinal", "mu", "sigma", "games"}}
+	for _, entry := range board.Top(limit) {
+		rows = append(rows, []string{
+			strconv.Itoa(entry.Rank),
+			entry.Player,
+			strconv.FormatFloat(entry.Score, 'f', 3, 64),
+			strconv.FormatFloat(entry.Rating.Mu, 'f', 3, 64),
+			strconv.FormatFloat(entry.Rating.Sigma, 'f', 3, 64),
+			strconv.Itoa(entry.Games),
+		})
+	}
+
+	if *format == "csv" {
+		return writeCSV(stdout, rows)
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMatches(t *testing.T) {
+	t.Parallel()
+
+	t.Run("csv", func(t *testing.T) {
+		input := "match,team,player,score,weight,time\n" +
+			"m1,red,alice,3,1,2024-01-01T10:00:00Z\n" +
+			"m1,blue,carol,1,,2024-01-01T10:00:00Z\n" +
+			"m1,red,bob,3,0.5,2024-01-01T10:00:00Z\n" +
+			"m2,x,bob,0,1,\n" +
+			"m2,y,carol,2,1,\n"
+
+		matches, err := readMatches(strings.NewReader(input), "csv")
+
+		assert.NoError(t, err)
+		assert.Len(t, matches, 2)
+		assert.Equal(t, "m1", matches[0].ID)
+		assert.Equal(t, 2024, matches[0].Time.Year())
+		assert.Equal(t, [][]string{{"alice", "bob"}, {"carol"}}, matches[0].Teams)
+		assert.Equal(t, [][]float64{{1, 0.5}, {1}}, matches[0].Weights)
+		assert.Equal(t, []float64{3, 1}, matches[0].Outcome.Scores())
+		assert.Equal(t, [][]string{{"bob"}, {"carol"}}, matches[1].Teams)
+		assert.True(t, matches[1].Time.IsZero())
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		input := `{"id": "m1", "teams": [["alice", "bob"], ["carol"]], "ranks": [2, 1]}` + "\n\n" +
+			`{"teams": [["alice"], ["carol"]]}` + "\n"
+
+		matches, err := readMatches(strings.NewReader(input), "jsonl")
+
+		assert.NoError(t, err)
+		assert.Len(t, matches, 2)
+		assert.Equal(t, []int{2, 1}, matches[0].Outcome.Ranks())
+		assert.Equal(t, openskill.NoOutcome, matches[1].Outcome.Kind())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := readMatches(strings.NewReader(`{"teams": [["a"], ["b"]], "ranks": [1, 2], "scores": [1, 2]}`), "jsonl")
+		assert.ErrorIs(t, err, openskill.ErrRanksAndScores)
+
+		_, err = readMatches(strings.NewReader("{"), "jsonl")
+		assert.ErrorContains(t, err, "line 1")
+
+		_, err = readMatches(strings.NewReader("match,player\nm1,alice\n"), "csv")
+		assert.ErrorContains(t, err, `missing column "team"`)
+
+		_, err = readMatches(strings.NewReader("match,team,player,rank\nm1,red,alice,first\n"), "csv")
+		assert.ErrorContains(t, err, "line 2: rank")
+
+		_, err = readMatches(strings.NewReader(""), "xml")
+		assert.ErrorContains(t, err, "unknown format")
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "ratings.json")
+	matchPath := filepath.Join(dir, "matches.csv")
+	assert.NoError(t, os.WriteFile(matchPath, []byte(
+		"match,team,player,rank\n"+
+			"m1,red,alice,1\n"+
+			"m1,blue,bob,2\n"+
+			"m2,red,alice,1\n"+
+			"m2,blue,carol,2\n"), 0o644))
+
+	run := func(args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		err := run(args, strings.NewReader(`{"teams": [["alice"], ["bob"]]}`), &stdout, &stderr)
+		return stdout.String(), err
+	}
+
+	t.Run("rate", func(t *testing.T) {
+		_, err := run("rate", "-state", statePath, "-model", "thurstone-mosteller-full", matchPath)
+		assert.NoError(t, err)
+
+		s, err := loadState(statePath)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, s.Players["alice"].Games)
+		assert.Equal(t, 1, s.Players["bob"].Games)
+		assert.Greater(t, s.Players["alice"].Mu, s.Players["bob"].Mu)
+	})
+
+	t.Run("predict", func(t *testing.T) {
+		out, err := run("predict", "-state", statePath, "-model", "thurstone-mosteller-full", "-")
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		assert.Equal(t, "match,team,players,win_probability,draw_probability,predicted_rank", lines[0])
+		assert.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[1], "1,1,alice,0.") && strings.HasSuffix(lines[1], ",1"), lines[1])
+	})
+
+	t.Run("leaderboard", func(t *testing.T) {
+		out, err := run("leaderboard", "-state", statePath, "-format", "csv", "-min-games", "2")
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		assert.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[1], "1,alice,"), lines[1])
+	})
+
+	t.Run("errors leave the state untouched", func(t *testing.T) {
+		before, err := os.ReadFile(statePath)
+		assert.NoError(t, err)
+
+		badPath := filepath.Join(dir, "bad.jsonl")
+		assert.NoError(t, os.WriteFile(badPath, []byte(
+			`{"teams": [["alice"], ["bob"]], "ranks": [1, 2]}`+"\n"+
+				`{"teams": [["alice"]], "ranks": [1]}`+"\n"), 0o644))
+
+		_, err = run("rate", "-state", statePath, badPath)
+		assert.ErrorIs(t, err, openskill.ErrLessThanTwoTeams)
+
+		after, err := os.ReadFile(statePath)
+		assert.NoError(t, err)
+		assert.Equal(t, before, after)
+
+		_, err = run("rate", "-state", statePath, "-model", "elo", matchPath)
+		assert.ErrorContains(t, err, "unknown model")
+
+		_, err = run("rate", "-state", statePath, "-model", "plackett-luce", "-sigma", "-1", matchPath)
+		assert.ErrorIs(t, err, openskill.ErrInvalidParameter)
+
+		_, err = run("fly")
+		assert.ErrorContains(t, err, "unknown command")
+	})
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	openskill "github.com/Sebsh1/openskill.go"
+)
+
+// state holds the ratings of every player between runs.
+type state struct {
+	Players map[string]playerState `json:"players"`
+}
+
+// playerState is the rating of a player and the number of games they have been rated in.
+type playerState struct {
+	Mu    float64 `json:"mu"`
+	Sigma float64 `json:"sigma"`
+	Games int     `json:"games"`
+}
+
+// loadState reads the state file at path, or returns an empty state if it does not exist yet.
+func loadState(path string) (state, error) {
+	s := state{Players: make(map[string]playerState)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return state{}, err
+	}
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return state{}, err
+	}
+	if s.Players == nil {
+		s.Players = make(map[string]playerState)
+	}
+	return s, nil
+}
+
+// save writes the state to path through a temporary file, so an interrupted run never leaves a partial state file.
+func (s state) save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ratings returns the rating of every player.
+func (s state) ratings() map[string]openskill.Rating {
+	ratings := make(map[string]openskill.Rating, len(s.Players))
+	for player, p := range s.Players {
+		ratings[player] = openskill.Rating{Mu: p.Mu, Sigma: p.Sigma}
+	}
+	return ratings
+}
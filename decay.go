@@ -0,0 +1,84 @@
+package openskill
+
+import (
+	"math"
+	"time"
+)
+
+// DecayCurve describes how much a player's sigma grows while they are inactive.
+type DecayCurve interface {
+	Inflate(sigma float64, inactive time.Duration) float64
+}
+
+type linearDecay struct {
+	rate   float64
+	period time.Duration
+}
+
+// LinearDecay returns a DecayCurve that increases sigma by rate for every period of inactivity.
+func LinearDecay(rate float64, period time.Duration) DecayCurve {
+	return linearDecay{rate: rate, period: period}
+}
+
+func (l linearDecay) Inflate(sigma float64, inactive time.Duration) float64 {
+	if l.period <= 0 {
+		return sigma
+	}
+	return sigma + l.rate*float64(inactive)/float64(l.period)
+}
+
+type sqrtTimeDecay struct {
+	rate   float64
+	period time.Duration
+}
+
+// SqrtTimeDecay returns a DecayCurve that adds rate² to the variance for every period of inactivity,
+// so sigma grows with the square root of the time inactive as in Glicko.
+func SqrtTimeDecay(rate float64, period time.Duration) DecayCurve {
+	return sqrtTimeDecay{rate: rate, period: period}
+}
+
+func (s sqrtTimeDecay) Inflate(sigma float64, inactive time.Duration) float64 {
+	if s.period <= 0 {
+		return sigma
+	}
+	return math.Sqrt(sigma*sigma + s.rate*s.rate*float64(inactive)/float64(s.period))
+}
+
+// InflateSigma returns the rating with its sigma inflated by the curve for the time between lastPlayed and now.
+// The inflated sigma is capped at maxSigma, but a sigma that is already above maxSigma is never reduced.
+// The rating is returned unchanged if the curve is nil, either time is zero or now is not after lastPlayed.
+func InflateSigma(r Rating, lastPlayed, now time.Time, curve DecayCurve, maxSigma float64) Rating {
+	if curve == nil || lastPlayed.IsZero() || now.IsZero() || !now.After(lastPlayed) {
+		return r
+	}
+
+	inflated := math.Min(curve.Inflate(r.Sigma, now.Sub(lastPlayed)), maxSigma)
+	return Rating{Mu: r.Mu, Sigma: math.Max(r.Sigma, inflated)}
+}
+
+// decayTeams returns the teams of the match with sigma inflated for the time since each player last played,
+// capped at maxSigma. The teams are returned unchanged if the curve is nil or the match has no time or LastPlayed.
+func decayTeams(match Match, curve DecayCurve, maxSigma float64) ([][]Rating, error) {
+	if curve == nil || match.Time.IsZero() || match.LastPlayed == nil {
+		return match.Teams, nil
+	}
+
+	if len(match.Teams) != len(match.LastPlayed) {
+		return nil, ErrLastPlayedAndTeamsMismatch
+	}
+
+	teams := make([][]Rating, len(match.Teams))
+	for i, team := range match.Teams {
+		if len(team) != len(match.LastPlayed[i]) {
+			return nil, ErrLastPlayedAndTeamsMismatch
+		}
+
+		teams[i] = make([]Rating, len(team))
+		for j, player := range team {
+			teams[i][j] = InflateSigma(player, match.LastPlayed[i][j], match.Time, curve, maxSigma)
+		}
+	}
+
+	return teams, nil
+}
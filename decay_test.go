@@ -0,0 +1,111 @@
+package openskill
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearDecay(t *testing.T) {
+	t.Parallel()
+
+	curve := LinearDecay(0.5, 24*time.Hour)
+
+	assert.Equal(t, 2.0, curve.Inflate(2, 0))
+	assert.Equal(t, 2.5, curve.Inflate(2, 24*time.Hour))
+	assert.Equal(t, 7.0, curve.Inflate(2, 10*24*time.Hour))
+	assert.Equal(t, 2.0, LinearDecay(0.5, 0).Inflate(2, time.Hour))
+}
+
+func TestSqrtTimeDecay(t *testing.T) {
+	t.Parallel()
+
+	curve := SqrtTimeDecay(1, 24*time.Hour)
+
+	assert.Equal(t, 3.0, curve.Inflate(3, 0))
+	assert.InDelta(t, 5.0, curve.Inflate(3, 16*24*time.Hour), delta)
+	assert.Equal(t, 3.0, SqrtTimeDecay(1, 0).Inflate(3, time.Hour))
+}
+
+func TestInflateSigma(t *testing.T) {
+	t.Parallel()
+
+	curve := LinearDecay(1, 24*time.Hour)
+	lastPlayed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := Rating{Mu: 30, Sigma: 2}
+
+	t.Run("inflates", func(t *testing.T) {
+		assert.Equal(t, Rating{Mu: 30, Sigma: 4}, InflateSigma(r, lastPlayed, lastPlayed.Add(48*time.Hour), curve, 8))
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		assert.Equal(t, Rating{Mu: 30, Sigma: 8}, InflateSigma(r, lastPlayed, lastPlayed.AddDate(1, 0, 0), curve, 8))
+	})
+
+	t.Run("never reduced by cap", func(t *testing.T) {
+		wide := Rating{Mu: 30, Sigma: 10}
+
+		assert.Equal(t, wide, InflateSigma(wide, lastPlayed, lastPlayed.AddDate(1, 0, 0), curve, 8))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Equal(t, r, InflateSigma(r, lastPlayed, lastPlayed.Add(48*time.Hour), nil, 8))
+		assert.Equal(t, r, InflateSigma(r, time.Time{}, lastPlayed, curve, 8))
+		assert.Equal(t, r, InflateSigma(r, lastPlayed, time.Time{}, curve, 8))
+		assert.Equal(t, r, InflateSigma(r, lastPlayed, lastPlayed.Add(-time.Hour), curve, 8))
+	})
+}
+
+func TestModelDecay(t *testing.T) {
+	t.Parallel()
+
+	lastPlayed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := lastPlayed.AddDate(0, 6, 0)
+	r := Rating{Mu: 30, Sigma: 2}
+
+	t.Run("without curve", func(t *testing.T) {
+		assert.Equal(t, r, DefaultThurstoneMostellerFullModel().Decay(r, lastPlayed, now))
+	})
+
+	t.Run("capped at prior", func(t *testing.T) {
+		m, err := NewPlackettLuceModelWithOptions(WithDecay(LinearDecay(1, 24*time.Hour)))
+		assert.NoError(t, err)
+
+		assert.Equal(t, Rating{Mu: 30, Sigma: 25.0 / 3.0}, m.Decay(r, lastPlayed, now))
+	})
+}
+
+func TestRateMatchDecay(t *testing.T) {
+	t.Parallel()
+
+	lastPlayed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := lastPlayed.Add(4 * 24 * time.Hour)
+	curve := LinearDecay(0.5, 24*time.Hour)
+	t1 := []Rating{{25, 2}}
+	t2 := []Rating{{25, 3}, {20, 4}}
+
+	for _, model := range allModels {
+		m, err := model.withOptions(WithDecay(curve))
+		assert.NoError(t, err)
+
+		expected, err := m.RateMatch(NewMatch([]Rating{{25, 4}}, []Rating{{25, 3}, {20, 6}}).WithRanks(2, 1))
+		assert.NoError(t, err)
+
+		actual, err := m.RateMatch(NewMatch(t1, t2).
+			WithRanks(2, 1).
+			WithTime(now).
+			WithLastPlayed([]time.Time{lastPlayed}, []time.Time{{}, lastPlayed}))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		withoutTime, err := m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1).WithLastPlayed([]time.Time{lastPlayed}, []time.Time{{}, lastPlayed}))
+		assert.NoError(t, err)
+		undecayed, err := m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1))
+		assert.NoError(t, err)
+		assert.Equal(t, undecayed, withoutTime)
+
+		_, err = m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1).WithTime(now).WithLastPlayed([]time.Time{lastPlayed}))
+		assert.ErrorIs(t, err, ErrLastPlayedAndTeamsMismatch)
+	}
+}
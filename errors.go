@@ -3,11 +3,21 @@ package openskill
 import "fmt"
 
 var (
-	ErrLessThanTwoTeams        = fmt.Errorf("less than two teams")
-	ErrEmptyTeam               = fmt.Errorf("empty team")
-	ErrNoRanksOrScores         = fmt.Errorf("ranks or scores must be provided")
-	ErrRanksAndScores          = fmt.Errorf("ranks and scores cannot be provided together")
-	ErrRanksAndTeamsMismatch   = fmt.Errorf("ranks must have same shape as teams")
-	ErrScoresAndTeamsMismatch  = fmt.Errorf("scores must have same shape as teams")
-	ErrWeightsAndTeamsMismatch = fmt.Errorf("weights must have same shape as teams")
+	ErrLessThanTwoTeams            = fmt.Errorf("less than two teams")
+	ErrEmptyTeam                   = fmt.Errorf("empty team")
+	ErrNoRanksOrScores             = fmt.Errorf("ranks or scores must be provided")
+	ErrRanksAndScores              = fmt.Errorf("ranks and scores cannot be provided together")
+	ErrRanksAndTeamsMismatch       = fmt.Errorf("ranks must have same shape as teams")
+	ErrScoresAndTeamsMismatch      = fmt.Errorf("scores must have same shape as teams")
+	ErrWeightsAndTeamsMismatch     = fmt.Errorf("weights must have same shape as teams")
+	ErrInvalidWeight               = fmt.Errorf("weights must be finite and positive after normalization")
+	ErrPartialPlayAndTeamsMismatch = fmt.Errorf("partial play must have same shape as teams")
+	ErrInvalidPartialPlay          = fmt.Errorf("partial play must be greater than 0 and at most 1")
+	ErrPartialPlayNotSupported     = fmt.Errorf("partial play is not supported by the rater")
+	ErrLastPlayedAndTeamsMismatch  = fmt.Errorf("last played must have same shape as teams")
+	ErrInvalidParameter            = fmt.Errorf("invalid model parameter")
+	ErrUnknownPlayer               = fmt.Errorf("unknown player")
+	ErrDuplicatePlayer             = fmt.Errorf("player appears more than once in a match")
+	ErrInvalidSampleCount          = fmt.Errorf("number of samples must be positive")
+	ErrUnknownModel                = fmt.Errorf("unknown model")
 )
@@ -0,0 +1,11 @@
+package evaluation
+
+import "fmt"
+
+var (
+	ErrNoPredictions                 = fmt.Errorf("no predictions to evaluate")
+	ErrProbabilitiesAndRanksMismatch = fmt.Errorf("probabilities and ranks must have the same length")
+	ErrLessThanTwoTeams              = fmt.Errorf("predictions must have at least two teams")
+	ErrPredictedRanksMismatch        = fmt.Errorf("predicted ranks must have the same length as ranks")
+	ErrInvalidBinCount               = fmt.Errorf("number of bins must be positive")
+)
@@ -0,0 +1,233 @@
+// Package evaluation measures how well the predictions of a Predictor match the outcomes of matches.
+package evaluation
+
+import (
+	"math"
+	"sort"
+
+	openskill "github.com/Sebsh1/openskill.go"
+)
+
+// minProbability keeps the log loss finite when a winner was predicted with probability 0.
+const minProbability = 1e-15
+
+// Prediction is a prediction of a match together with its actual outcome.
+type Prediction struct {
+	// Probabilities is the predicted chance of each team winning.
+	Probabilities []float64
+	// PredictedRanks is the predicted ranking of the teams, where a lower rank is better.
+	// If nil, the teams are ranked by Probabilities.
+	PredictedRanks []int
+	// Ranks is the actual ranking of the teams, where a lower rank is better.
+	Ranks []int
+}
+
+// Predict predicts a match with the Predictor and pairs the prediction with its actual ranks.
+func Predict(p openskill.Predictor, teams [][]openskill.Rating, ranks []int) (Prediction, error) {
+	predictedRanks, probabilities, err := p.ChanceOfRanks(teams)
+	if err != nil {
+		return Prediction{}, err
+	}
+	if len(ranks) != len(teams) {
+		return Prediction{}, ErrProbabilitiesAndRanksMismatch
+	}
+
+	return Prediction{
+		Probabilities:  probabilities,
+		PredictedRanks: predictedRanks,
+		Ranks:          append([]int(nil), ranks...),
+	}, nil
+}
+
+// Bin is a bin of a reliability curve.
+type Bin struct {
+	// Lower and Upper are the bounds of the predicted probabilities in the bin.
+	Lower, Upper float64
+	// Count is the number of predicted probabilities in the bin.
+	Count int
+	// MeanPredicted is the mean predicted probability in the bin.
+	MeanPredicted float64
+	// Observed is the fraction of the predictions in the bin that won, where a win shared by tied teams is split
+	// between them.
+	Observed float64
+}
+
+// Report summarizes how well predictions match outcomes. Each team's chance of winning is compared to whether it won,
+// where a win shared by k tied teams counts as 1/k for each of them.
+type Report struct {
+	Matches int
+	// LogLoss is the mean negative log likelihood of the winners. Lower is better.
+	LogLoss float64
+	// Brier is the mean squared error of the chances of winning, summed over the teams of a match. Lower is better.
+	Brier float64
+	// Accuracy is the fraction of matches won by the team with the highest chance of winning.
+	Accuracy float64
+	// RankCorrelation is the mean Spearman correlation between the predicted and actual ranks over the matches where
+	// it is defined, which excludes matches where all teams tied or all were predicted equal. It is 0 if no match
+	// qualifies.
+	RankCorrelation float64
+	// Reliability is the reliability curve of the chances of winning. A well calibrated predictor has an Observed
+	// frequency close to MeanPredicted in every bin.
+	Reliability []Bin
+}
+
+// Evaluate scores the predictions, grouping the chances of winning into the given number of equally wide bins for the
+// reliability curve. Every prediction must cover at least two teams.
+func Evaluate(predictions []Prediction, bins int) (Report, error) {
+	if len(predictions) == 0 {
+		return Report{}, ErrNoPredictions
+	}
+	if bins <= 0 {
+		return Report{}, ErrInvalidBinCount
+	}
+
+	for _, prediction := range predictions {
+		if len(prediction.Probabilities) != len(prediction.Ranks) {
+			return Report{}, ErrProbabilitiesAndRanksMismatch
+		}
+		if len(prediction.Ranks) < 2 {
+			return Report{}, ErrLessThanTwoTeams
+		}
+		if prediction.PredictedRanks != nil && len(prediction.PredictedRanks) != len(prediction.Ranks) {
+			return Report{}, ErrPredictedRanksMismatch
+		}
+	}
+
+	report := Report{Matches: len(predictions), Reliability: make([]Bin, bins)}
+	for i := range report.Reliability {
+		report.Reliability[i].Lower = float64(i) / float64(bins)
+		report.Reliability[i].Upper = float64(i+1) / float64(bins)
+	}
+
+	correlations := 0
+	for _, prediction := range predictions {
+		observed := outcomes(prediction.Ranks)
+
+		favourite := 0
+		for i, p := range prediction.Probabilities {
+			if p > prediction.Probabilities[favourite] {
+				favourite = i
+			}
+
+			if observed[i] > 0 {
+				report.LogLoss -= observed[i] * math.Log(math.Max(p, minProbability))
+			}
+			report.Brier += (p - observed[i]) * (p - observed[i])
+
+			bin := min(int(p*float64(bins)), bins-1)
+			bin = max(bin, 0)
+			report.Reliability[bin].Count++
+			report.Reliability[bin].MeanPredicted += p
+			report.Reliability[bin].Observed += observed[i]
+		}
+		if observed[favourite] > 0 {
+			report.Accuracy++
+		}
+
+		predictedRanks := prediction.PredictedRanks
+		if predictedRanks == nil {
+			predictedRanks = make([]int, len(prediction.Probabilities))
+			for i, p := range prediction.Probabilities {
+				for _, q := range prediction.Probabilities {
+					if q > p {
+						predictedRanks[i]++
+					}
+				}
+			}
+		}
+		if correlation, ok := spearman(predictedRanks, prediction.Ranks); ok {
+			report.RankCorrelation += correlation
+			correlations++
+		}
+	}
+
+	n := float64(len(predictions))
+	report.LogLoss /= n
+	report.Brier /= n
+	report.Accuracy /= n
+	if correlations > 0 {
+		report.RankCorrelation /= float64(correlations)
+	}
+	for i := range report.Reliability {
+		if bin := &report.Reliability[i]; bin.Count > 0 {
+			bin.MeanPredicted /= float64(bin.Count)
+			bin.Observed /= float64(bin.Count)
+		}
+	}
+
+	return report, nil
+}
+
+// outcomes returns 1/k for each of the k teams sharing the best rank and 0 for the other teams.
+func outcomes(ranks []int) []float64 {
+	best := math.MaxInt
+	for _, rank := range ranks {
+		best = min(best, rank)
+	}
+
+	winners := 0
+	for _, rank := range ranks {
+		if rank == best {
+			winners++
+		}
+	}
+
+	result := make([]float64, len(ranks))
+	for i, rank := range ranks {
+		if rank == best {
+			result[i] = 1 / float64(winners)
+		}
+	}
+	return result
+}
+
+// spearman returns the Spearman rank correlation between two rankings, with tied ranks replaced by their average
+// position, or false if either ranking has all teams tied.
+func spearman(a, b []int) (float64, bool) {
+	x, y := averageRanks(a), averageRanks(b)
+
+	meanX, meanY := 0.0, 0.0
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	covariance, varianceX, varianceY := 0.0, 0.0, 0.0
+	for i := range x {
+		covariance += (x[i] - meanX) * (y[i] - meanY)
+		varianceX += (x[i] - meanX) * (x[i] - meanX)
+		varianceY += (y[i] - meanY) * (y[i] - meanY)
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return 0, false
+	}
+	return covariance / math.Sqrt(varianceX*varianceY), true
+}
+
+// averageRanks returns the position of each team in the ranking starting at 1, where tied teams share the average of
+// their positions.
+func averageRanks(ranks []int) []float64 {
+	order := make([]int, len(ranks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return ranks[order[i]] < ranks[order[j]]
+	})
+
+	result := make([]float64, len(ranks))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && ranks[order[end]] == ranks[order[start]] {
+			end++
+		}
+		average := float64(start+end+1) / 2
+		for _, i := range order[start:end] {
+			result[i] = average
+		}
+		start = end
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package evaluation
+
+import (
+	"math"
+	"testing"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("two teams", func(t *testing.T) {
+		predictions := []Prediction{
+			{Probabilities: []float64{0.8, 0.2}, Ranks: []int{1, 2}},
+			{Probabilities: []float64{0.6, 0.4}, Ranks: []int{2, 1}},
+		}
+
+		report, err := Evaluate(predictions, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Matches)
+		assert.InDelta(t, -(math.Log(0.8)+math.Log(0.4))/2, report.LogLoss, 1e-12)
+		assert.InDelta(t, (0.04+0.04+0.36+0.36)/2, report.Brier, 1e-12)
+		assert.InDelta(t, 0.5, report.Accuracy, 1e-12)
+		assert.InDelta(t, 0, report.RankCorrelation, 1e-12)
+
+		assert.Len(t, report.Reliability, 10)
+		assert.Equal(t, Bin{Lower: 0.8, Upper: 0.9, Count: 1, MeanPredicted: 0.8, Observed: 1}, report.Reliability[8])
+		assert.Equal(t, 1, report.Reliability[6].Count)
+		assert.Equal(t, 0.0, report.Reliability[6].Observed)
+		assert.Equal(t, 0, report.Reliability[5].Count)
+	})
+
+	t.Run("ties split the win", func(t *testing.T) {
+		predictions := []Prediction{
+			{Probabilities: []float64{0.5, 0.3, 0.2}, Ranks: []int{1, 1, 3}},
+		}
+
+		report, err := Evaluate(predictions, 2)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, -(0.5*math.Log(0.5) + 0.5*math.Log(0.3)), report.LogLoss, 1e-12)
+		assert.InDelta(t, 0.0+0.04+0.04, report.Brier, 1e-12)
+		assert.InDelta(t, 1, report.Accuracy, 1e-12)
+		assert.InDelta(t, math.Sqrt(0.75), report.RankCorrelation, 1e-12)
+		assert.Equal(t, Bin{Lower: 0.5, Upper: 1, Count: 1, MeanPredicted: 0.5, Observed: 0.5}, report.Reliability[1])
+	})
+
+	t.Run("rank correlation", func(t *testing.T) {
+		predictions := []Prediction{
+			{Probabilities: []float64{0.5, 0.3, 0.2}, Ranks: []int{1, 2, 3}},
+			{Probabilities: []float64{0.5, 0.3, 0.2}, PredictedRanks: []int{1, 2, 3}, Ranks: []int{3, 2, 1}},
+			{Probabilities: []float64{0.5, 0.5}, Ranks: []int{1, 2}},
+		}
+
+		report, err := Evaluate(predictions, 10)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 0, report.RankCorrelation, 1e-12)
+	})
+
+	t.Run("impossible winner", func(t *testing.T) {
+		report, err := Evaluate([]Prediction{{Probabilities: []float64{1, 0}, Ranks: []int{2, 1}}}, 10)
+
+		assert.NoError(t, err)
+		assert.False(t, math.IsInf(report.LogLoss, 0))
+		assert.Equal(t, 1, report.Reliability[9].Count)
+		assert.Equal(t, 1, report.Reliability[0].Count)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Evaluate(nil, 10)
+		assert.ErrorIs(t, err, ErrNoPredictions)
+
+		_, err = Evaluate([]Prediction{{Probabilities: []float64{0.5, 0.5}, Ranks: []int{1, 2}}}, 0)
+		assert.ErrorIs(t, err, ErrInvalidBinCount)
+
+		_, err = Evaluate([]Prediction{{Probabilities: []float64{0.5, 0.5}, Ranks: []int{1}}}, 10)
+		assert.ErrorIs(t, err, ErrProbabilitiesAndRanksMismatch)
+
+		_, err = Evaluate([]Prediction{{Probabilities: []float64{0.5, 0.5}, PredictedRanks: []int{1}, Ranks: []int{1, 2}}}, 10)
+		assert.ErrorIs(t, err, ErrPredictedRanksMismatch)
+
+		_, err = Evaluate([]Prediction{{}}, 10)
+		assert.ErrorIs(t, err, ErrLessThanTwoTeams)
+
+		_, err = Evaluate([]Prediction{{Probabilities: []float64{1}, Ranks: []int{1}}}, 10)
+		assert.ErrorIs(t, err, ErrLessThanTwoTeams)
+
+		valid := Prediction{Probabilities: []float64{0.5, 0.5}, Ranks: []int{1, 2}}
+		_, err = Evaluate([]Prediction{valid, {Probabilities: []float64{1, 0}}}, 10)
+		assert.ErrorIs(t, err, ErrProbabilitiesAndRanksMismatch)
+	})
+}
+
+func TestPredict(t *testing.T) {
+	t.Parallel()
+
+	p := openskill.DefaultPredictor()
+	teams := [][]openskill.Rating{{{Mu: 30, Sigma: 3}}, {{Mu: 20, Sigma: 3}}, {{Mu: 25, Sigma: 3}}}
+
+	prediction, err := Predict(p, teams, []int{1, 3, 2})
+	assert.NoError(t, err)
+
+	ranks, probabilities, err := p.ChanceOfRanks(teams)
+	assert.NoError(t, err)
+	assert.Equal(t, Prediction{Probabilities: probabilities, PredictedRanks: ranks, Ranks: []int{1, 3, 2}}, prediction)
+
+	report, err := Evaluate([]Prediction{prediction}, 10)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, report.RankCorrelation, 1e-12)
+	assert.InDelta(t, 1, report.Accuracy, 1e-12)
+
+	_, err = Predict(p, teams, []int{1, 2})
+	assert.ErrorIs(t, err, ErrProbabilitiesAndRanksMismatch)
+
+	_, err = Predict(p, teams[:1], []int{1})
+	assert.ErrorIs(t, err, openskill.ErrLessThanTwoTeams)
+}
@@ -0,0 +1,152 @@
+package openskill
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// FileStore is a RatingStore that appends the ratings of every match as a line of JSON to a file.
+// Opening the file again replays it, so the latest ratings are restored. It is safe for concurrent use.
+// The keys must be encodable and decodable with encoding/json.
+type FileStore[K comparable] struct {
+	mu      sync.Mutex
+	file    *os.File
+	size    int64
+	ratings *MemoryStore[K]
+}
+
+// fileStoreRecord is a line in the file of a FileStore.
+type fileStoreRecord[K comparable] struct {
+	Match   string               `json:"match"`
+	Ratings []fileStorePlayer[K] `json:"ratings"`
+}
+
+// fileStorePlayer is the rating of a player in a fileStoreRecord.
+type fileStorePlayer[K comparable] struct {
+	Player K       `json:"player"`
+	Mu     float64 `json:"mu"`
+	Sigma  float64 `json:"sigma"`
+}
+
+// OpenFileStore opens or creates the file at path and replays it. An incomplete last line, as left behind by a crash
+// while writing, is discarded so the match it belonged to is not applied. Close the store when done.
+func OpenFileStore[K comparable](path string) (*FileStore[K], error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, validSize, err := replayFileStore[K](file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("replaying %s: %w", path, err)
+	}
+
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &FileStore[K]{file: file, size: validSize, ratings: ratings}, nil
+}
+
+// replayFileStore applies every complete line of the file and returns the resulting ratings and the size of the complete lines.
+func replayFileStore[K comparable](file *os.File) (*MemoryStore[K], int64, error) {
+	ratings := NewMemoryStore[K]()
+	reader := bufio.NewReader(file)
+	var size int64
+
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return ratings, size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var record fileStoreRecord[K]
+		if err := json.Unmarshal(bytes.TrimSpace(line), &record); err != nil {
+			return nil, 0, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		updated := make(map[K]Rating, len(record.Ratings))
+		for _, player := range record.Ratings {
+			updated[player.Player] = Rating{Mu: player.Mu, Sigma: player.Sigma}
+		}
+		if err := ratings.Put(record.Match, updated); err != nil {
+			return nil, 0, err
+		}
+		size += int64(len(line))
+	}
+}
+
+// Get returns the rating of the player, or false if the player has no rating.
+func (f *FileStore[K]) Get(player K) (Rating, bool, error) {
+	return f.ratings.Get(player)
+}
+
+// GetMany returns the ratings of the players that have one.
+func (f *FileStore[K]) GetMany(players []K) (map[K]Rating, error) {
+	return f.ratings.GetMany(players)
+}
+
+// Put appends the updated ratings of the players in a match to the file as a single line and syncs it to disk.
+func (f *FileStore[K]) Put(matchID string, ratings map[K]Rating) error {
+	record := fileStoreRecord[K]{Match: matchID, Ratings: make([]fileStorePlayer[K], 0, len(ratings))}
+	for player, r := range ratings {
+		record.Ratings = append(record.Ratings, fileStorePlayer[K]{Player: player, Mu: r.Mu, Sigma: r.Sigma})
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.file.Write(line); err != nil {
+		return f.rollback(err)
+	}
+	if err := f.file.Sync(); err != nil {
+		return f.rollback(err)
+	}
+	f.size += int64(len(line))
+
+	return f.ratings.Put(matchID, ratings)
+}
+
+// rollback removes a partially written line from the file so later lines are not appended to it.
+func (f *FileStore[K]) rollback(err error) error {
+	if truncateErr := f.file.Truncate(f.size); truncateErr != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", err, truncateErr)
+	}
+	if _, seekErr := f.file.Seek(f.size, io.SeekStart); seekErr != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", err, seekErr)
+	}
+	return err
+}
+
+// All returns a copy of every stored rating.
+func (f *FileStore[K]) All() map[K]Rating {
+	return f.ratings.All()
+}
+
+// Close closes the underlying file.
+func (f *FileStore[K]) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
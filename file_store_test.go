@@ -0,0 +1,99 @@
+package openskill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reopen replays", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratings.jsonl")
+
+		s, err := OpenFileStore[string](path)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Put("1", map[string]Rating{"alice": {30, 5}, "bob": {20, 4}}))
+		assert.NoError(t, s.Put("2", map[string]Rating{"alice": {31, 4}}))
+
+		r, ok, err := s.Get("alice")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, Rating{31, 4}, r)
+		assert.NoError(t, s.Close())
+
+		reopened, err := OpenFileStore[string](path)
+		assert.NoError(t, err)
+		defer reopened.Close()
+
+		many, err := reopened.GetMany([]string{"alice", "bob", "carol"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]Rating{"alice": {31, 4}, "bob": {20, 4}}, many)
+		assert.Equal(t, many, reopened.All())
+	})
+
+	t.Run("integer keys", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratings.jsonl")
+
+		s, err := OpenFileStore[int](path)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Put("1", map[int]Rating{7: {30, 5}}))
+		assert.NoError(t, s.Close())
+
+		reopened, err := OpenFileStore[int](path)
+		assert.NoError(t, err)
+		defer reopened.Close()
+
+		assert.Equal(t, map[int]Rating{7: {30, 5}}, reopened.All())
+	})
+
+	t.Run("incomplete last line is discarded", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratings.jsonl")
+		content := `{"match":"1","ratings":[{"player":"alice","mu":30,"sigma":5}]}` + "\n" +
+			`{"match":"2","ratings":[{"player":"alice","mu":`
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		s, err := OpenFileStore[string](path)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]Rating{"alice": {30, 5}}, s.All())
+
+		assert.NoError(t, s.Put("3", map[string]Rating{"bob": {20, 4}}))
+		assert.NoError(t, s.Close())
+
+		reopened, err := OpenFileStore[string](path)
+		assert.NoError(t, err)
+		defer reopened.Close()
+
+		assert.Equal(t, map[string]Rating{"alice": {30, 5}, "bob": {20, 4}}, reopened.All())
+	})
+
+	t.Run("corrupt line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratings.jsonl")
+		assert.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o644))
+
+		_, err := OpenFileStore[string](path)
+
+		assert.ErrorContains(t, err, "line 1")
+	})
+
+	t.Run("rate and store", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratings.jsonl")
+		m := DefaultPlackettLuceModel()
+		match := PlayerMatch[string]{ID: "1", Teams: [][]string{{"alice"}, {"bob"}}, Outcome: Ranks(1, 2)}
+
+		s, err := OpenFileStore[string](path)
+		assert.NoError(t, err)
+		updated, err := RateAndStore[string](m, s, match)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Close())
+
+		reopened, err := OpenFileStore[string](path)
+		assert.NoError(t, err)
+		defer reopened.Close()
+
+		assert.Equal(t, updated, reopened.All())
+	})
+}
@@ -0,0 +1,149 @@
+package openskill
+
+import (
+	"sync"
+	"time"
+)
+
+// HistoryEntry is a change to a player's rating caused by a match.
+type HistoryEntry struct {
+	MatchID string
+	Time    time.Time
+	Before  Rating
+	After   Rating
+}
+
+// HistoryStore stores the rating changes of players. Implement it to keep the history in your own storage.
+type HistoryStore[K comparable] interface {
+	// Append adds an entry to the timeline of the player.
+	Append(player K, entry HistoryEntry) error
+	// Entries returns the timeline of the player in the order the entries were appended.
+	Entries(player K) ([]HistoryEntry, error)
+}
+
+// MemoryHistory is a HistoryStore that keeps the history in memory. It is safe for concurrent use.
+type MemoryHistory[K comparable] struct {
+	mu      sync.RWMutex
+	entries map[K][]HistoryEntry
+}
+
+// NewMemoryHistory returns an empty MemoryHistory.
+func NewMemoryHistory[K comparable]() *MemoryHistory[K] {
+	return &MemoryHistory[K]{entries: make(map[K][]HistoryEntry)}
+}
+
+// Append adds an entry to the timeline of the player.
+func (m *MemoryHistory[K]) Append(player K, entry HistoryEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[player] = append(m.entries[player], entry)
+	return nil
+}
+
+// Entries returns a copy of the timeline of the player.
+func (m *MemoryHistory[K]) Entries(player K) ([]HistoryEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]HistoryEntry{}, m.entries[player]...), nil
+}
+
+// History records the rating changes of the players in every match rated through it and answers queries about them.
+type History[K comparable] struct {
+	store HistoryStore[K]
+}
+
+// NewHistory returns a History backed by the store.
+func NewHistory[K comparable](store HistoryStore[K]) *History[K] {
+	return &History[K]{store: store}
+}
+
+// RatePlayers rates the match like RatePlayers and records the change of every player's rating in the history.
+// The ratings are returned even if recording fails, in which case some players may be missing the entry for the match.
+func (h *History[K]) RatePlayers(r Rater, match PlayerMatch[K], ratings map[K]Rating) (map[K]Rating, error) {
+	before, after, err := ratePlayers(r, match, ratings)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, player := range match.Players() {
+		entry := HistoryEntry{
+			MatchID: match.ID,
+			Time:    match.Time,
+			Before:  before[player],
+			After:   after[player],
+		}
+		if err := h.store.Append(player, entry); err != nil {
+			return after, err
+		}
+	}
+
+	return after, nil
+}
+
+// Timeline returns every recorded change of the player's rating.
+func (h *History[K]) Timeline(player K) ([]HistoryEntry, error) {
+	return h.store.Entries(player)
+}
+
+// RatingAt returns the player's rating at time t, which is the rating after the latest match played at or before t.
+// False is returned if the player has no match recorded at or before t.
+func (h *History[K]) RatingAt(player K, t time.Time) (Rating, bool, error) {
+	entries, err := h.store.Entries(player)
+	if err != nil {
+		return Rating{}, false, err
+	}
+
+	var latest *HistoryEntry
+	for i := range entries {
+		if entries[i].Time.After(t) {
+			continue
+		}
+		if latest == nil || !entries[i].Time.Before(latest.Time) {
+			latest = &entries[i]
+		}
+	}
+
+	if latest == nil {
+		return Rating{}, false, nil
+	}
+	return latest.After, true, nil
+}
+
+// LastChanges returns the last n recorded changes of the player's rating, oldest first.
+func (h *History[K]) LastChanges(player K, n int) ([]HistoryEntry, error) {
+	entries, err := h.store.Entries(player)
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 {
+		n = 0
+	}
+	if n < len(entries) {
+		entries = entries[len(entries)-n:]
+	}
+	return entries, nil
+}
+
+// PeakOrdinal returns the entry after which the player's rating had its highest ordinal.
+// False is returned if the player has no recorded changes.
+func (h *History[K]) PeakOrdinal(player K) (HistoryEntry, bool, error) {
+	entries, err := h.store.Entries(player)
+	if err != nil {
+		return HistoryEntry{}, false, err
+	}
+
+	if len(entries) == 0 {
+		return HistoryEntry{}, false, nil
+	}
+
+	peak := entries[0]
+	for _, entry := range entries[1:] {
+		if entry.After.Ordinal() > peak.After.Ordinal() {
+			peak = entry
+		}
+	}
+	return peak, true, nil
+}
@@ -0,0 +1,166 @@
+package openskill
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingHistory is a HistoryStore that fails every call.
+type failingHistory struct{}
+
+func (failingHistory) Append(string, HistoryEntry) error {
+	return errors.New("append failed")
+}
+
+func (failingHistory) Entries(string) ([]HistoryEntry, error) {
+	return nil, errors.New("entries failed")
+}
+
+func TestMemoryHistory(t *testing.T) {
+	t.Parallel()
+
+	h := NewMemoryHistory[string]()
+	entry := HistoryEntry{MatchID: "1", Before: Rating{25, 8}, After: Rating{27, 7}}
+
+	assert.NoError(t, h.Append("alice", entry))
+
+	entries, err := h.Entries("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, []HistoryEntry{entry}, entries)
+
+	entries[0].MatchID = "changed"
+	entries, err = h.Entries("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", entries[0].MatchID)
+
+	entries, err = h.Entries("bob")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestHistoryRatePlayers(t *testing.T) {
+	t.Parallel()
+
+	m := DefaultThurstoneMostellerFullModel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("records every player", func(t *testing.T) {
+		h := NewHistory[string](NewMemoryHistory[string]())
+		ratings := map[string]Rating{"alice": {30, 5}}
+		match := PlayerMatch[string]{
+			ID:      "match-1",
+			Time:    start,
+			Teams:   [][]string{{"alice"}, {"bob"}},
+			Outcome: Ranks(1, 2),
+		}
+
+		updated, err := h.RatePlayers(m, match, ratings)
+		assert.NoError(t, err)
+
+		alice, err := h.Timeline("alice")
+		assert.NoError(t, err)
+		assert.Equal(t, []HistoryEntry{{MatchID: "match-1", Time: start, Before: Rating{30, 5}, After: updated["alice"]}}, alice)
+
+		bob, err := h.Timeline("bob")
+		assert.NoError(t, err)
+		assert.Equal(t, []HistoryEntry{{MatchID: "match-1", Time: start, Before: m.NewRating(), After: updated["bob"]}}, bob)
+	})
+
+	t.Run("invalid match is not recorded", func(t *testing.T) {
+		h := NewHistory[string](NewMemoryHistory[string]())
+		match := PlayerMatch[string]{Teams: [][]string{{"alice"}, {"bob"}}}
+
+		_, err := h.RatePlayers(m, match, nil)
+		assert.ErrorIs(t, err, ErrNoRanksOrScores)
+
+		alice, err := h.Timeline("alice")
+		assert.NoError(t, err)
+		assert.Empty(t, alice)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		h := NewHistory[string](failingHistory{})
+		match := PlayerMatch[string]{Teams: [][]string{{"alice"}, {"bob"}}, Outcome: Ranks(1, 2)}
+
+		updated, err := h.RatePlayers(m, match, nil)
+
+		assert.Error(t, err)
+		assert.Len(t, updated, 2)
+	})
+}
+
+func TestHistoryQueries(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryHistory[int]()
+	entries := []HistoryEntry{
+		{MatchID: "1", Time: start, Before: Rating{25, 8}, After: Rating{28, 7}},
+		{MatchID: "2", Time: start.Add(time.Hour), Before: Rating{28, 7}, After: Rating{33, 5}},
+		{MatchID: "3", Time: start.Add(2 * time.Hour), Before: Rating{33, 5}, After: Rating{30, 3}},
+	}
+	for _, entry := range entries {
+		assert.NoError(t, store.Append(1, entry))
+	}
+	h := NewHistory[int](store)
+
+	t.Run("rating at", func(t *testing.T) {
+		_, ok, err := h.RatingAt(1, start.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		r, ok, err := h.RatingAt(1, start)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, Rating{28, 7}, r)
+
+		r, ok, err = h.RatingAt(1, start.Add(90*time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, Rating{33, 5}, r)
+
+		r, ok, err = h.RatingAt(1, start.AddDate(1, 0, 0))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, Rating{30, 3}, r)
+	})
+
+	t.Run("last changes", func(t *testing.T) {
+		last, err := h.LastChanges(1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, entries[1:], last)
+
+		last, err = h.LastChanges(1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, entries, last)
+
+		last, err = h.LastChanges(1, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, last)
+	})
+
+	t.Run("peak ordinal", func(t *testing.T) {
+		peak, ok, err := h.PeakOrdinal(1)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, entries[2], peak)
+
+		_, ok, err = h.PeakOrdinal(2)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		failing := NewHistory[string](failingHistory{})
+
+		_, _, err := failing.RatingAt("alice", start)
+		assert.Error(t, err)
+		_, err = failing.LastChanges("alice", 1)
+		assert.Error(t, err)
+		_, _, err = failing.PeakOrdinal("alice")
+		assert.Error(t, err)
+	})
+}
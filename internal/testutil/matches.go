@@ -0,0 +1,40 @@
+// Package testutil provides fixtures shared by the tests of several packages.
+package testutil
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	openskill "github.com/Sebsh1/openskill.go"
+)
+
+// SyntheticMatches returns 1v1 matches between players with a fixed true skill, won by the stronger player with the
+// probability of a logistic model, one match per hour starting at start. The same n and start always give the same
+// matches.
+func SyntheticMatches(n int, start time.Time) []openskill.PlayerMatch[int] {
+	rng := rand.New(rand.NewPCG(7, 8))
+	skills := make([]float64, 20)
+	for i := range skills {
+		skills[i] = float64(i) * 2
+	}
+
+	matches := make([]openskill.PlayerMatch[int], n)
+	for i := range matches {
+		a, b := rng.IntN(len(skills)), rng.IntN(len(skills)-1)
+		if b >= a {
+			b++
+		}
+		ranks := []int{1, 2}
+		if rng.Float64() > 1/(1+math.Exp((skills[b]-skills[a])/5)) {
+			ranks = []int{2, 1}
+		}
+		matches[i] = openskill.PlayerMatch[int]{
+			ID:      string(rune('a' + i%26)),
+			Time:    start.Add(time.Duration(i) * time.Hour),
+			Teams:   [][]int{{a}, {b}},
+			Outcome: openskill.Ranks(ranks...),
+		}
+	}
+	return matches
+}
@@ -0,0 +1,331 @@
+package openskill
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// LeaderboardEntry is a player on a Leaderboard.
+type LeaderboardEntry[K comparable] struct {
+	Player K
+	Rating Rating
+	// Score is the display score the leaderboard is sorted by.
+	Score float64
+	// Games is the number of games the player has played.
+	Games int
+	// Rank is the position of the player, starting at 1. Players with the same score share a rank.
+	Rank int
+}
+
+// LeaderboardOption configures a Leaderboard.
+type LeaderboardOption func(*leaderboardOptions)
+
+type leaderboardOptions struct {
+	score    func(Rating) float64
+	minGames int
+}
+
+// WithDisplayScore sets the score players are sorted by, Rating.Ordinal by default.
+func WithDisplayScore(score func(Rating) float64) LeaderboardOption {
+	return func(o *leaderboardOptions) {
+		o.score = score
+	}
+}
+
+// WithMinGames hides players who have played fewer than n games from the leaderboard.
+func WithMinGames(n int) LeaderboardOption {
+	return func(o *leaderboardOptions) {
+		o.minGames = n
+	}
+}
+
+// Leaderboard keeps players sorted by a display score, highest first. Players with the same score share a rank and are
+// listed in the order they reached it. Updates and rank queries take O(log n) time. It is safe for concurrent use.
+type Leaderboard[K comparable] struct {
+	mu       sync.RWMutex
+	score    func(Rating) float64
+	minGames int
+	players  map[K]*leaderboardNode[K]
+	root     *leaderboardNode[K]
+	seq      uint64
+}
+
+// leaderboardNode is a node of the treap holding the players that are on the leaderboard.
+type leaderboardNode[K comparable] struct {
+	player   K
+	rating   Rating
+	score    float64
+	games    int
+	seq      uint64
+	listed   bool
+	priority uint64
+	size     int
+	left     *leaderboardNode[K]
+	right    *leaderboardNode[K]
+}
+
+// NewLeaderboard returns an empty Leaderboard.
+func NewLeaderboard[K comparable](opts ...LeaderboardOption) *Leaderboard[K] {
+	o := leaderboardOptions{score: Rating.Ordinal}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Leaderboard[K]{score: o.score, minGames: o.minGames, players: make(map[K]*leaderboardNode[K])}
+}
+
+// Update sets the rating of a player after a game, counting the game towards the minimum number of games.
+func (l *Leaderboard[K]) Update(player K, rating Rating) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	games := 1
+	if n, ok := l.players[player]; ok {
+		games = n.games + 1
+	}
+	l.set(player, rating, games)
+}
+
+// UpdateMany updates the ratings of the players in a rated match, e.g. as returned by RatePlayers.
+func (l *Leaderboard[K]) UpdateMany(ratings map[K]Rating) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for player, rating := range ratings {
+		games := 1
+		if n, ok := l.players[player]; ok {
+			games = n.games + 1
+		}
+		l.set(player, rating, games)
+	}
+}
+
+// Set sets the rating and number of games of a player, e.g. when loading the leaderboard from a RatingStore.
+func (l *Leaderboard[K]) Set(player K, rating Rating, games int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.set(player, rating, games)
+}
+
+// Remove removes a player from the leaderboard.
+func (l *Leaderboard[K]) Remove(player K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n, ok := l.players[player]; ok {
+		l.unlist(n)
+		delete(l.players, player)
+	}
+}
+
+// Len returns the number of players on the leaderboard, not counting those below the minimum number of games.
+func (l *Leaderboard[K]) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.root.count()
+}
+
+// Entry returns the entry of a player, or false if the player is not on the leaderboard.
+func (l *Leaderboard[K]) Entry(player K) (LeaderboardEntry[K], bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	n, ok := l.players[player]
+	if !ok || !n.listed {
+		return LeaderboardEntry[K]{}, false
+	}
+	return l.entry(n), true
+}
+
+// Rank returns the rank of a player, or false if the player is not on the leaderboard.
+func (l *Leaderboard[K]) Rank(player K) (int, bool) {
+	e, ok := l.Entry(player)
+	return e.Rank, ok
+}
+
+// Percentile returns the percentage of players on the leaderboard whose score is at most the score of the player,
+// or false if the player is not on the leaderboard.
+func (l *Leaderboard[K]) Percentile(player K) (float64, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	n, ok := l.players[player]
+	if !ok || !n.listed {
+		return 0, false
+	}
+	total := l.root.count()
+	return 100 * float64(total-l.root.countAbove(n.score)) / float64(total), true
+}
+
+// Top returns the k highest ranked players.
+func (l *Leaderboard[K]) Top(k int) []LeaderboardEntry[K] {
+	return l.Page(0, k)
+}
+
+// Page returns up to limit players starting at the given offset, where offset 0 is the highest ranked player.
+func (l *Leaderboard[K]) Page(offset, limit int) []LeaderboardEntry[K] {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 || offset >= l.root.count() {
+		return nil
+	}
+
+	nodes := make([]*leaderboardNode[K], 0, min(limit, l.root.count()-offset))
+	l.root.collect(offset, limit, &nodes)
+
+	entries := make([]LeaderboardEntry[K], len(nodes))
+	for i, n := range nodes {
+		entries[i] = l.entry(n)
+		if i > 0 && n.score == nodes[i-1].score {
+			entries[i].Rank = entries[i-1].Rank
+		} else if i > 0 {
+			entries[i].Rank = offset + i + 1
+		}
+	}
+	return entries
+}
+
+// entry returns the entry of a listed node. The caller must hold the lock.
+func (l *Leaderboard[K]) entry(n *leaderboardNode[K]) LeaderboardEntry[K] {
+	return LeaderboardEntry[K]{
+		Player: n.player,
+		Rating: n.rating,
+		Score:  n.score,
+		Games:  n.games,
+		Rank:   l.root.countAbove(n.score) + 1,
+	}
+}
+
+// set updates a player and moves them to their new position. The caller must hold the lock.
+func (l *Leaderboard[K]) set(player K, rating Rating, games int) {
+	n, ok := l.players[player]
+	if ok {
+		l.unlist(n)
+	} else {
+		n = &leaderboardNode[K]{player: player}
+		l.players[player] = n
+	}
+
+	l.seq++
+	n.rating, n.score, n.games, n.seq = rating, l.score(rating), games, l.seq
+	if games >= l.minGames {
+		n.priority, n.size, n.left, n.right, n.listed = rand.Uint64(), 1, nil, nil, true
+		before, after := l.root.split(n)
+		l.root = mergeLeaderboard(mergeLeaderboard(before, n), after)
+	}
+}
+
+// unlist removes a node from the treap if it is in it. The caller must hold the lock.
+func (l *Leaderboard[K]) unlist(n *leaderboardNode[K]) {
+	if !n.listed {
+		return
+	}
+	before, rest := l.root.split(n)
+	_, after := rest.splitAfter(n)
+	l.root = mergeLeaderboard(before, after)
+	n.listed = false
+}
+
+// ranksBefore reports whether n is listed before other.
+func (n *leaderboardNode[K]) ranksBefore(other *leaderboardNode[K]) bool {
+	return n.score > other.score || (n.score == other.score && n.seq < other.seq)
+}
+
+func (n *leaderboardNode[K]) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *leaderboardNode[K]) update() {
+	n.size = n.left.count() + n.right.count() + 1
+}
+
+// split splits the treap into the nodes listed before key and the remaining nodes.
+func (n *leaderboardNode[K]) split(key *leaderboardNode[K]) (*leaderboardNode[K], *leaderboardNode[K]) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.ranksBefore(key) {
+		before, after := n.right.split(key)
+		n.right = before
+		n.update()
+		return n, after
+	}
+	before, after := n.left.split(key)
+	n.left = after
+	n.update()
+	return before, n
+}
+
+// splitAfter splits the treap into the nodes up to and including key and the remaining nodes.
+func (n *leaderboardNode[K]) splitAfter(key *leaderboardNode[K]) (*leaderboardNode[K], *leaderboardNode[K]) {
+	if n == nil {
+		return nil, nil
+	}
+	if n == key || n.ranksBefore(key) {
+		before, after := n.right.splitAfter(key)
+		n.right = before
+		n.update()
+		return n, after
+	}
+	before, after := n.left.splitAfter(key)
+	n.left = after
+	n.update()
+	return before, n
+}
+
+// mergeLeaderboard joins two treaps where every node in a is listed before every node in b.
+func mergeLeaderboard[K comparable](a, b *leaderboardNode[K]) *leaderboardNode[K] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = mergeLeaderboard(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = mergeLeaderboard(a, b.left)
+	b.update()
+	return b
+}
+
+// countAbove returns the number of nodes with a score higher than score.
+func (n *leaderboardNode[K]) countAbove(score float64) int {
+	count := 0
+	for n != nil {
+		if n.score > score {
+			count += n.left.count() + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return count
+}
+
+// collect appends up to limit nodes in order, skipping the first offset nodes.
+func (n *leaderboardNode[K]) collect(offset, limit int, nodes *[]*leaderboardNode[K]) {
+	if n == nil || len(*nodes) >= limit {
+		return
+	}
+	leftSize := n.left.count()
+	if offset < leftSize {
+		n.left.collect(offset, limit, nodes)
+	}
+	if offset <= leftSize && len(*nodes) < limit {
+		*nodes = append(*nodes, n)
+	}
+	if len(*nodes) < limit {
+		n.right.collect(max(offset-leftSize-1, 0), limit, nodes)
+	}
+}
@@ -0,0 +1,172 @@
+package openskill
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sorted by ordinal", func(t *testing.T) {
+		l := NewLeaderboard[string]()
+		l.Update("alice", Rating{30, 3})
+		l.Update("bob", Rating{35, 3})
+		l.Update("carol", Rating{25, 1})
+
+		top := l.Top(2)
+		assert.Equal(t, []LeaderboardEntry[string]{
+			{Player: "bob", Rating: Rating{35, 3}, Score: Rating{35, 3}.Ordinal(), Games: 1, Rank: 1},
+			{Player: "carol", Rating: Rating{25, 1}, Score: Rating{25, 1}.Ordinal(), Games: 1, Rank: 2},
+		}, top)
+
+		rank, ok := l.Rank("alice")
+		assert.True(t, ok)
+		assert.Equal(t, 3, rank)
+		assert.Equal(t, 3, l.Len())
+	})
+
+	t.Run("incremental updates", func(t *testing.T) {
+		l := NewLeaderboard[string]()
+		l.UpdateMany(map[string]Rating{"alice": {30, 1}, "bob": {20, 1}})
+		l.UpdateMany(map[string]Rating{"alice": {10, 1}, "bob": {25, 1}})
+
+		e, ok := l.Entry("bob")
+		assert.True(t, ok)
+		assert.Equal(t, 1, e.Rank)
+		assert.Equal(t, 2, e.Games)
+
+		l.Remove("bob")
+		_, ok = l.Entry("bob")
+		assert.False(t, ok)
+		assert.Equal(t, 1, l.Len())
+	})
+
+	t.Run("ties share a rank", func(t *testing.T) {
+		l := NewLeaderboard[string](WithDisplayScore(func(r Rating) float64 { return r.Mu }))
+		l.Update("alice", Rating{30, 1})
+		l.Update("bob", Rating{20, 1})
+		l.Update("carol", Rating{30, 5})
+		l.Update("dave", Rating{20, 2})
+
+		page := l.Page(1, 3)
+		assert.Equal(t, []string{"carol", "bob", "dave"}, leaderboardPlayers(page))
+		assert.Equal(t, []int{1, 3, 3}, leaderboardRanks(page))
+
+		percentile, ok := l.Percentile("bob")
+		assert.True(t, ok)
+		assert.Equal(t, 50.0, percentile)
+		percentile, _ = l.Percentile("alice")
+		assert.Equal(t, 100.0, percentile)
+	})
+
+	t.Run("minimum games", func(t *testing.T) {
+		l := NewLeaderboard[string](WithMinGames(2))
+		l.Update("alice", Rating{30, 1})
+		l.Set("bob", Rating{20, 1}, 5)
+
+		_, ok := l.Rank("alice")
+		assert.False(t, ok)
+		_, ok = l.Percentile("alice")
+		assert.False(t, ok)
+		assert.Equal(t, []string{"bob"}, leaderboardPlayers(l.Top(10)))
+
+		l.Update("alice", Rating{30, 1})
+		assert.Equal(t, []string{"alice", "bob"}, leaderboardPlayers(l.Top(10)))
+	})
+
+	t.Run("pagination bounds", func(t *testing.T) {
+		l := NewLeaderboard[int]()
+		for i := range 5 {
+			l.Update(i, Rating{float64(i), 1})
+		}
+
+		assert.Equal(t, []int{4, 3}, leaderboardPlayers(l.Page(-1, 2)))
+		assert.Equal(t, []int{0}, leaderboardPlayers(l.Page(4, 2)))
+		assert.Nil(t, l.Page(5, 2))
+		assert.Nil(t, l.Page(0, 0))
+	})
+
+	t.Run("matches sorting", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		l := NewLeaderboard[int](WithDisplayScore(func(r Rating) float64 { return math.Round(r.Mu) }), WithMinGames(2))
+		ratings := make(map[int]Rating)
+		games := make(map[int]int)
+		order := make(map[int]int)
+
+		for i := range 5000 {
+			player := rng.IntN(500)
+			if rng.IntN(20) == 0 {
+				l.Remove(player)
+				delete(ratings, player)
+				delete(games, player)
+				continue
+			}
+			r := Rating{rng.Float64() * 50, 1}
+			l.Update(player, r)
+			ratings[player] = r
+			games[player]++
+			order[player] = i
+		}
+
+		var expected []int
+		for player := range ratings {
+			if games[player] >= 2 {
+				expected = append(expected, player)
+			}
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			a, b := math.Round(ratings[expected[i]].Mu), math.Round(ratings[expected[j]].Mu)
+			return a > b || (a == b && order[expected[i]] < order[expected[j]])
+		})
+
+		assert.Equal(t, len(expected), l.Len())
+		assert.Equal(t, expected, leaderboardPlayers(l.Page(0, l.Len())))
+		assert.Equal(t, expected[100:150], leaderboardPlayers(l.Page(100, 50)))
+
+		for i, player := range expected {
+			rank, ok := l.Rank(player)
+			assert.True(t, ok)
+			expectedRank := i + 1
+			for expectedRank > 1 && math.Round(ratings[expected[expectedRank-2]].Mu) == math.Round(ratings[player].Mu) {
+				expectedRank--
+			}
+			assert.Equal(t, expectedRank, rank)
+		}
+	})
+}
+
+func leaderboardPlayers[K comparable](entries []LeaderboardEntry[K]) []K {
+	var players []K
+	for _, e := range entries {
+		players = append(players, e.Player)
+	}
+	return players
+}
+
+func leaderboardRanks[K comparable](entries []LeaderboardEntry[K]) []int {
+	var ranks []int
+	for _, e := range entries {
+		ranks = append(ranks, e.Rank)
+	}
+	return ranks
+}
+
+func BenchmarkLeaderboardUpdate(b *testing.B) {
+	l := NewLeaderboard[int]()
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range 1_000_000 {
+		l.Update(i, Rating{rng.Float64() * 50, rng.Float64() * 8})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		player := rng.IntN(1_000_000)
+		l.Update(player, Rating{rng.Float64() * 50, rng.Float64() * 8})
+		l.Rank(player)
+	}
+}
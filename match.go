@@ -0,0 +1,161 @@
+package openskill
+
+import "time"
+
+// OutcomeKind describes how the result of a match is expressed.
+type OutcomeKind int
+
+const (
+	// NoOutcome is the kind of the zero Outcome, which cannot be rated.
+	NoOutcome OutcomeKind = iota
+	// RankOutcome is the kind of an outcome where lower ranks are better and equal ranks are draws.
+	RankOutcome
+	// ScoreOutcome is the kind of an outcome where higher scores are better and equal scores are draws.
+	ScoreOutcome
+)
+
+// Outcome is the result of a match, expressed either as a rank or a score per team.
+type Outcome struct {
+	kind   OutcomeKind
+	ranks  []int
+	scores []float64
+}
+
+// Ranks returns an outcome with a rank per team, where lower ranks are better and equal ranks are draws.
+func Ranks(ranks ...int) Outcome {
+	return Outcome{kind: RankOutcome, ranks: append([]int{}, ranks...)}
+}
+
+// Scores returns an outcome with a score per team, where higher scores are better and equal scores are draws.
+func Scores(scores ...float64) Outcome {
+	return Outcome{kind: ScoreOutcome, scores: append([]float64{}, scores...)}
+}
+
+// Kind returns whether the outcome is expressed as ranks or scores.
+func (o Outcome) Kind() OutcomeKind {
+	return o.kind
+}
+
+// Ranks returns a copy of the ranks of the outcome, or nil if it is not a RankOutcome.
+func (o Outcome) Ranks() []int {
+	if o.kind != RankOutcome {
+		return nil
+	}
+	return append([]int{}, o.ranks...)
+}
+
+// Scores returns a copy of the scores of the outcome, or nil if it is not a ScoreOutcome.
+func (o Outcome) Scores() []float64 {
+	if o.kind != ScoreOutcome {
+		return nil
+	}
+	return append([]float64{}, o.scores...)
+}
+
+// Ranking returns the ranks of the outcome, where a lower rank is better. Scores are converted to ranks where a higher
+// score is better and only exactly equal scores tie. It returns nil for NoOutcome.
+// Use Model.Ranking to get the ranks a model rates the outcome with, which applies the model's tie tolerance.
+func (o Outcome) Ranking() []int {
+	return o.ranking(tieTolerance{})
+}
+
+// ranking returns the ranks of the outcome, where scores within the tolerance of each other tie.
+func (o Outcome) ranking(tolerance tieTolerance) []int {
+	switch o.kind {
+	case RankOutcome:
+		return o.Ranks()
+	case ScoreOutcome:
+		return scoresToRanks(o.scores, tolerance)
+	}
+	return nil
+}
+
+// Match describes a match between teams and its outcome, which can be rated with Model.RateMatch.
+type Match struct {
+	// ID optionally identifies the match.
+	ID string
+	// Time optionally records when the match was played.
+	Time time.Time
+	// Teams are the ratings of the players of each team before the match.
+	Teams [][]Rating
+	// Outcome is the result of the match.
+	Outcome Outcome
+	// Weights optionally describe the contribution of each player to their team.
+	Weights [][]float64
+	// PartialPlay optionally describes each player's share of participation in the match in (0, 1],
+	// e.g. 0.25 for a player who played 10 of 40 minutes. It scales both the player's contribution
+	// to the team and the size of their own update, independently of Weights.
+	PartialPlay [][]float64
+	// LastPlayed optionally records when each player last played before the match, which is used together
+	// with Time to inflate the sigma of inactive players if the model has a decay curve. A zero time means unknown.
+	LastPlayed [][]time.Time
+}
+
+// NewMatch returns a match between the teams. Use the With... methods to add the outcome and optional details.
+func NewMatch(teams ...[]Rating) Match {
+	return Match{Teams: teams}
+}
+
+// WithRanks returns a copy of the match with an outcome where lower ranks are better.
+func (m Match) WithRanks(ranks ...int) Match {
+	m.Outcome = Ranks(ranks...)
+	return m
+}
+
+// WithScores returns a copy of the match with an outcome where higher scores are better.
+func (m Match) WithScores(scores ...float64) Match {
+	m.Outcome = Scores(scores...)
+	return m
+}
+
+// WithOutcome returns a copy of the match with the outcome.
+func (m Match) WithOutcome(outcome Outcome) Match {
+	m.Outcome = outcome
+	return m
+}
+
+// WithWeights returns a copy of the match with a weight per player describing their contribution to the team.
+func (m Match) WithWeights(weights ...[]float64) Match {
+	m.Weights = weights
+	return m
+}
+
+// WithPartialPlay returns a copy of the match with each player's share of participation in the match.
+func (m Match) WithPartialPlay(partialPlay ...[]float64) Match {
+	m.PartialPlay = partialPlay
+	return m
+}
+
+// WithLastPlayed returns a copy of the match with the time each player last played before the match.
+func (m Match) WithLastPlayed(lastPlayed ...[]time.Time) Match {
+	m.LastPlayed = lastPlayed
+	return m
+}
+
+// WithID returns a copy of the match with the ID.
+func (m Match) WithID(id string) Match {
+	m.ID = id
+	return m
+}
+
+// WithTime returns a copy of the match with the time it was played.
+func (m Match) WithTime(t time.Time) Match {
+	m.Time = t
+	return m
+}
+
+// rateMatch rates the match with a plain Rater, which only accepts integer scores, so scores are passed as ranks instead.
+func rateMatch(r Rater, match Match) ([][]Rating, error) {
+	if match.PartialPlay != nil {
+		return nil, ErrPartialPlayNotSupported
+	}
+
+	ranks := match.Outcome.Ranks()
+	if scores := match.Outcome.Scores(); scores != nil {
+		if len(scores) != len(match.Teams) {
+			return nil, ErrScoresAndTeamsMismatch
+		}
+		ranks = scoresToRanks(scores, tieTolerance{})
+	}
+	return r.Rate(match.Teams, ranks, nil, match.Weights)
+}
@@ -0,0 +1,408 @@
+package openskill
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutcome(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero value", func(t *testing.T) {
+		var o Outcome
+
+		assert.Equal(t, NoOutcome, o.Kind())
+		assert.Nil(t, o.Ranks())
+		assert.Nil(t, o.Scores())
+		assert.Nil(t, o.Ranking())
+	})
+
+	t.Run("ranks", func(t *testing.T) {
+		ranks := []int{2, 1}
+		o := Ranks(ranks...)
+		ranks[0] = 5
+
+		assert.Equal(t, RankOutcome, o.Kind())
+		assert.Equal(t, []int{2, 1}, o.Ranks())
+		assert.Equal(t, []int{2, 1}, o.Ranking())
+		assert.Nil(t, o.Scores())
+	})
+
+	t.Run("scores", func(t *testing.T) {
+		o := Scores(10, 5, 10)
+
+		assert.Equal(t, ScoreOutcome, o.Kind())
+		assert.Equal(t, []float64{10, 5, 10}, o.Scores())
+		assert.Equal(t, []int{1, 3, 1}, o.Ranking())
+		assert.Nil(t, o.Ranks())
+	})
+
+	t.Run("returned slices are copies", func(t *testing.T) {
+		o := Ranks(2, 1)
+		o.Ranks()[0] = 5
+
+		assert.Equal(t, []int{2, 1}, o.Ranks())
+	})
+}
+
+func TestModelRanking(t *testing.T) {
+	t.Parallel()
+
+	for _, model := range allModels {
+		m, err := model.withOptions(WithAbsoluteTieTolerance(0.5))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []int{1, 1, 3}, m.Ranking(Scores(10, 10.2, 3)))
+		assert.Equal(t, []int{2, 1, 3}, Scores(10, 10.2, 3).Ranking())
+		assert.Equal(t, []int{2, 1}, m.Ranking(Ranks(2, 1)))
+		assert.Nil(t, m.Ranking(Outcome{}))
+	}
+}
+
+func TestNewMatch(t *testing.T) {
+	t.Parallel()
+
+	t1 := []Rating{{25, 8}}
+	t2 := []Rating{{20, 4}, {30, 2}}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	m := NewMatch(t1, t2).
+		WithScores(3, 1).
+		WithWeights([]float64{1}, []float64{0.5, 1}).
+		WithID("match-1").
+		WithTime(now)
+
+	assert.Equal(t, Match{
+		ID:      "match-1",
+		Time:    now,
+		Teams:   [][]Rating{t1, t2},
+		Outcome: Scores(3, 1),
+		Weights: [][]float64{{1}, {0.5, 1}},
+	}, m)
+	assert.Equal(t, Ranks(1, 2), m.WithRanks(1, 2).Outcome)
+	assert.Equal(t, Ranks(1, 2), m.WithOutcome(Ranks(1, 2)).Outcome)
+	assert.Equal(t, Scores(3, 1), m.Outcome)
+}
+
+func TestRateMatch(t *testing.T) {
+	t.Parallel()
+
+	models := defaultModels()
+
+	t1 := []Rating{{25, 8}}
+	t2 := []Rating{{20, 4}, {30, 2}}
+
+	t.Run("ranks", func(t *testing.T) {
+		for _, m := range models {
+			expected, err := m.Rate([][]Rating{t1, t2}, []int{1, 2}, nil, nil)
+			assert.NoError(t, err)
+
+			actual, err := m.RateMatch(NewMatch(t1, t2).WithRanks(1, 2))
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("scores", func(t *testing.T) {
+		for _, m := range models {
+			expected, err := m.Rate([][]Rating{t1, t2}, nil, []int{10, 5}, nil)
+			assert.NoError(t, err)
+
+			actual, err := m.RateMatch(NewMatch(t1, t2).WithScores(10, 5))
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("ranks are not mutated", func(t *testing.T) {
+		match := NewMatch(t1, t2).WithRanks(2, 1)
+
+		_, err := DefaultPlackettLuceModel().RateMatch(match)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []int{2, 1}, match.Outcome.Ranks())
+	})
+
+	t.Run("unsorted ranks", func(t *testing.T) {
+		t3 := []Rating{{28, 5}}
+		for _, m := range models {
+			unsorted, err := m.Rate([][]Rating{t1, t2, t3}, []int{2, 3, 1}, nil, nil)
+			assert.NoError(t, err)
+
+			sorted, err := m.Rate([][]Rating{t3, t1, t2}, []int{1, 2, 3}, nil, nil)
+			assert.NoError(t, err)
+
+			assert.Equal(t, [][]Rating{sorted[1], sorted[2], sorted[0]}, unsorted)
+		}
+	})
+
+	t.Run("one result per team", func(t *testing.T) {
+		teams := [][]Rating{t1, t2, {{28, 5}}}
+		for _, m := range models {
+			result, err := m.Rate(teams, []int{2, 1, 3}, nil, nil)
+			assert.NoError(t, err)
+
+			assert.Len(t, result, len(teams))
+			for i, team := range result {
+				assert.Len(t, team, len(teams[i]))
+			}
+		}
+	})
+
+	t.Run("missing outcome", func(t *testing.T) {
+		for _, m := range models {
+			_, err := m.RateMatch(NewMatch(t1, t2))
+
+			assert.ErrorIs(t, err, ErrNoRanksOrScores)
+		}
+	})
+}
+
+func TestRateMatchMarginOfVictory(t *testing.T) {
+	t.Parallel()
+
+	constructors := []func(...Option) (Model, error){
+		NewPlackettLuceModelWithOptions,
+		NewBradlyTerryFullModelWithOptions,
+		NewBradlyTerryPartialModelWithOptions,
+	}
+
+	t1 := []Rating{{25, 8}}
+	t2 := []Rating{{25, 8}}
+
+	for _, constructor := range constructors {
+		plain, err := constructor()
+		assert.NoError(t, err)
+		mov, err := constructor(WithMarginOfVictory(1))
+		assert.NoError(t, err)
+
+		closePlain, err := plain.RateMatch(NewMatch(t1, t2).WithScores(10, 9))
+		assert.NoError(t, err)
+		widePlain, err := plain.RateMatch(NewMatch(t1, t2).WithScores(10, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, closePlain, widePlain)
+
+		closeMov, err := mov.RateMatch(NewMatch(t1, t2).WithScores(10, 9))
+		assert.NoError(t, err)
+		wideMov, err := mov.RateMatch(NewMatch(t1, t2).WithScores(10, 0))
+		assert.NoError(t, err)
+
+		closeChange := math.Abs(closeMov[1][0].Mu - t2[0].Mu)
+		wideChange := math.Abs(wideMov[1][0].Mu - t2[0].Mu)
+		assert.Greater(t, wideChange, closeChange)
+		assert.Greater(t, closeChange, math.Abs(closePlain[1][0].Mu-t2[0].Mu))
+		assert.Equal(t, closePlain[1][0].Sigma, closeMov[1][0].Sigma)
+	}
+}
+
+func TestRateMatchFractionalScores(t *testing.T) {
+	t.Parallel()
+
+	m := DefaultThurstoneMostellerFullModel()
+	t1 := []Rating{{25, 8}}
+	t2 := []Rating{{25, 8}}
+
+	expected, err := m.Rate([][]Rating{t1, t2}, []int{2, 1}, nil, nil)
+	assert.NoError(t, err)
+
+	actual, err := m.RateMatch(NewMatch(t1, t2).WithScores(9.58, 9.63))
+	assert.NoError(t, err)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestRateMatchTieTolerance(t *testing.T) {
+	t.Parallel()
+
+	t1 := []Rating{{20, 8}}
+	t2 := []Rating{{25, 6}}
+	t3 := []Rating{{30, 4}}
+
+	for _, model := range allModels {
+		m, err := model.withOptions(WithAbsoluteTieTolerance(0.1))
+		assert.NoError(t, err)
+
+		expected, err := m.RateMatch(NewMatch(t1, t2, t3).WithRanks(2, 1, 1))
+		assert.NoError(t, err)
+
+		actual, err := m.RateMatch(NewMatch(t1, t2, t3).WithScores(1, 10, 10.05))
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+
+		outside, err := m.RateMatch(NewMatch(t1, t2, t3).WithScores(1, 10, 10.5))
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, expected, outside)
+	}
+}
+
+func TestRateMatchPartialPlay(t *testing.T) {
+	t.Parallel()
+
+	models := defaultModels()
+
+	t1 := []Rating{{25, 8}}
+	t2 := []Rating{{25, 8}, {25, 8}}
+
+	t.Run("full participation", func(t *testing.T) {
+		for _, m := range models {
+			expected, err := m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1))
+			assert.NoError(t, err)
+
+			actual, err := m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1).WithPartialPlay([]float64{1}, []float64{1, 1}))
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("partial participation scales own update", func(t *testing.T) {
+		m := DefaultThurstoneMostellerFullModel()
+
+		actual, err := m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1).WithPartialPlay([]float64{1}, []float64{0.25, 1}))
+		assert.NoError(t, err)
+
+		assert.Less(t, actual[0][0].Mu, t1[0].Mu)
+		assert.Greater(t, actual[1][0].Mu, t2[0].Mu)
+		assert.Less(t, actual[1][0].Mu-t2[0].Mu, actual[1][1].Mu-t2[1].Mu)
+		assert.Greater(t, actual[1][0].Sigma, actual[1][1].Sigma)
+	})
+
+	t.Run("partial play is independent of weights", func(t *testing.T) {
+		m := DefaultThurstoneMostellerFullModel()
+		match := NewMatch(t1, t2).WithRanks(2, 1)
+
+		weighted, err := m.RateMatch(match.WithWeights([]float64{1}, []float64{0.25, 1}))
+		assert.NoError(t, err)
+		partial, err := m.RateMatch(match.WithPartialPlay([]float64{1}, []float64{0.25, 1}))
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, weighted, partial)
+	})
+
+	t.Run("limit sigma keeps partial play", func(t *testing.T) {
+		match := NewMatch(t1, t2).WithRanks(2, 1)
+		partial := match.WithPartialPlay([]float64{0.1}, []float64{1, 1})
+
+		for _, model := range allModels {
+			unlimited, err := model.withOptions()
+			assert.NoError(t, err)
+			limited, err := model.withOptions(WithLimitSigma(true))
+			assert.NoError(t, err)
+
+			expected, err := unlimited.RateMatch(partial)
+			assert.NoError(t, err)
+			actual, err := limited.RateMatch(partial)
+			assert.NoError(t, err)
+			full, err := limited.RateMatch(match)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected[0][0].Mu, actual[0][0].Mu)
+			assert.Less(t, math.Abs(actual[0][0].Mu-t1[0].Mu), math.Abs(full[0][0].Mu-t1[0].Mu)/2)
+			assert.LessOrEqual(t, actual[0][0].Sigma, expected[0][0].Sigma)
+		}
+	})
+
+	t.Run("invalid partial play", func(t *testing.T) {
+		for _, m := range models {
+			_, err := m.RateMatch(NewMatch(t1, t2).WithRanks(1, 2).WithPartialPlay([]float64{1}))
+
+			assert.ErrorIs(t, err, ErrPartialPlayAndTeamsMismatch)
+		}
+	})
+
+	t.Run("plain rater", func(t *testing.T) {
+		_, err := rateMatch(identityRater{}, NewMatch(t1, t2).WithRanks(1, 2).WithPartialPlay([]float64{1}, []float64{1, 1}))
+
+		assert.ErrorIs(t, err, ErrPartialPlayNotSupported)
+	})
+}
+
+func TestRateMatchWeights(t *testing.T) {
+	t.Parallel()
+
+	models := defaultModels()
+
+	t1 := []Rating{{25, 8}}
+	t2 := []Rating{{20, 6}, {30, 4}}
+
+	t.Run("weights are not mutated", func(t *testing.T) {
+		for _, m := range models {
+			weights := [][]float64{{0.5}, {0.2, 0.8}}
+
+			_, err := m.Rate([][]Rating{t1, t2}, []int{2, 1}, nil, weights)
+			assert.NoError(t, err)
+
+			assert.Equal(t, [][]float64{{0.5}, {0.2, 0.8}}, weights)
+		}
+	})
+
+	t.Run("weights follow their team", func(t *testing.T) {
+		for _, m := range models {
+			forward, err := m.RateMatch(NewMatch(t2, t1).WithRanks(1, 2).WithWeights([]float64{0.2, 0.8}, []float64{1}))
+			assert.NoError(t, err)
+
+			reversed, err := m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1).WithWeights([]float64{1}, []float64{0.2, 0.8}))
+			assert.NoError(t, err)
+
+			assert.Equal(t, forward[0], reversed[1])
+		}
+	})
+
+	t.Run("invalid weights", func(t *testing.T) {
+		for _, model := range allModels {
+			m, err := model.withOptions(WithWeightStrategy(RawWeights()))
+			assert.NoError(t, err)
+
+			_, err = m.RateMatch(NewMatch(t1, t2).WithRanks(2, 1).WithWeights([]float64{1}, []float64{0, 1}))
+			assert.ErrorIs(t, err, ErrInvalidWeight)
+		}
+	})
+
+	t.Run("equal weights are unweighted", func(t *testing.T) {
+		strategies := []WeightStrategy{MinMaxWeights(1, 2), SumToOneWeights(), RawWeights(), SoftmaxWeights()}
+		t3 := []Rating{{22, 5}, {26, 7}, {24, 3}}
+		match := NewMatch(t2, t3).WithRanks(2, 1)
+
+		for _, model := range allModels {
+			for _, strategy := range strategies {
+				m, err := model.withOptions(WithWeightStrategy(strategy))
+				assert.NoError(t, err)
+
+				expected, err := m.RateMatch(match)
+				assert.NoError(t, err)
+				actual, err := m.RateMatch(match.WithWeights([]float64{1, 1}, []float64{1, 1, 1}))
+				assert.NoError(t, err)
+
+				for i := range expected {
+					for j := range expected[i] {
+						assert.InDelta(t, expected[i][j].Mu, actual[i][j].Mu, 1e-9)
+						assert.InDelta(t, expected[i][j].Sigma, actual[i][j].Sigma, 1e-9)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("strategy", func(t *testing.T) {
+		minMax, err := NewThurstoneMostellerFullModelWithOptions()
+		assert.NoError(t, err)
+		sumToOne, err := NewThurstoneMostellerFullModelWithOptions(WithWeightStrategy(SumToOneWeights()))
+		assert.NoError(t, err)
+
+		match := NewMatch(t1, t2).WithRanks(2, 1).WithWeights([]float64{1}, []float64{1, 3})
+
+		minMaxResult, err := minMax.RateMatch(match)
+		assert.NoError(t, err)
+		sumToOneResult, err := sumToOne.RateMatch(match)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, minMaxResult, sumToOneResult)
+	})
+}
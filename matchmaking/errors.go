@@ -0,0 +1,11 @@
+package matchmaking
+
+import "fmt"
+
+var (
+	ErrInvalidTeamShape = fmt.Errorf("players cannot be split into the requested number and size of teams")
+	ErrDuplicatePlayer  = fmt.Errorf("player appears more than once in the pool or in parties")
+	ErrUnknownPlayer    = fmt.Errorf("party member is not in the pool")
+	ErrPartyTooLarge    = fmt.Errorf("party is larger than the team size")
+	ErrNoValidPartition = fmt.Errorf("parties cannot be split into teams of the requested size")
+)
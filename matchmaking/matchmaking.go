@@ -0,0 +1,332 @@
+// Package matchmaking splits a pool of rated players into balanced teams.
+package matchmaking
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	openskill "github.com/Sebsh1/openskill.go"
+)
+
+// DefaultExactThreshold is the largest number of parties and solo players that are split exactly by default.
+const DefaultExactThreshold = 12
+
+// defaultMaxPasses limits the number of improvement passes of the heuristic search.
+const defaultMaxPasses = 100
+
+// Player is a player in the pool.
+type Player[K comparable] struct {
+	ID     K
+	Rating openskill.Rating
+}
+
+// Request describes how to split a pool of players into teams. Either Teams or TeamSize must be set, and every player
+// in the pool is placed on a team.
+type Request[K comparable] struct {
+	Players []Player[K]
+	// Teams is the number of teams. If 0, it is derived from TeamSize.
+	Teams int
+	// TeamSize is the number of players on each team. If 0, it is derived from Teams.
+	TeamSize int
+	// Parties are groups of players that must be on the same team.
+	Parties [][]K
+	// ExactThreshold is the largest number of parties and solo players for which every possible split is tried.
+	// Larger pools are split by a greedy assignment improved by swapping players and parties between teams.
+	// If 0, DefaultExactThreshold is used, and a negative value always uses the heuristic.
+	ExactThreshold int
+}
+
+// Result is a split of the pool into teams.
+type Result[K comparable] struct {
+	Teams [][]K
+	// WinProbabilities is the chance of each team winning as predicted by the Predictor.
+	WinProbabilities []float64
+	// Imbalance is the difference between the highest and lowest chance of winning, 0 for a perfectly fair match.
+	Imbalance float64
+}
+
+// unit is a party or a solo player, which is always placed on a team as a whole.
+type unit struct {
+	players []int
+	mu      float64
+}
+
+// balancer holds the state of a search for the most balanced split.
+type balancer[K comparable] struct {
+	predictor openskill.Predictor
+	players   []Player[K]
+	units     []unit
+	teams     int
+	teamSize  int
+
+	assignment []int
+	sizes      []int
+	best       []int
+	bestResult Result[K]
+	err        error
+}
+
+// Balance splits the players into teams so that the difference between the chances of winning predicted by the
+// Predictor is as small as possible.
+func Balance[K comparable](predictor openskill.Predictor, req Request[K]) (Result[K], error) {
+	teams, teamSize, err := teamShape(len(req.Players), req.Teams, req.TeamSize)
+	if err != nil {
+		return Result[K]{}, err
+	}
+
+	units, err := buildUnits(req.Players, req.Parties, teamSize)
+	if err != nil {
+		return Result[K]{}, err
+	}
+
+	threshold := req.ExactThreshold
+	if threshold == 0 {
+		threshold = DefaultExactThreshold
+	}
+
+	b := &balancer[K]{
+		predictor:  predictor,
+		players:    req.Players,
+		units:      units,
+		teams:      teams,
+		teamSize:   teamSize,
+		assignment: make([]int, len(units)),
+		sizes:      make([]int, teams),
+		bestResult: Result[K]{Imbalance: math.Inf(1)},
+	}
+
+	if len(units) <= threshold {
+		b.exact(0)
+	} else {
+		b.heuristic()
+	}
+
+	if b.err != nil {
+		return Result[K]{}, b.err
+	}
+	if b.best == nil {
+		return Result[K]{}, ErrNoValidPartition
+	}
+	return b.bestResult, nil
+}
+
+// teamShape returns the number and size of teams for a pool of n players.
+func teamShape(n, teams, teamSize int) (int, int, error) {
+	switch {
+	case teams == 0 && teamSize > 0 && n%teamSize == 0:
+		teams = n / teamSize
+	case teamSize == 0 && teams > 0 && n%teams == 0:
+		teamSize = n / teams
+	}
+
+	if teams < 2 || teamSize < 1 || teams*teamSize != n {
+		return 0, 0, ErrInvalidTeamShape
+	}
+	return teams, teamSize, nil
+}
+
+// buildUnits groups the players into parties and solo players, keeping the order of the pool.
+func buildUnits[K comparable](players []Player[K], parties [][]K, teamSize int) ([]unit, error) {
+	index := make(map[K]int, len(players))
+	for i, p := range players {
+		if _, ok := index[p.ID]; ok {
+			return nil, ErrDuplicatePlayer
+		}
+		index[p.ID] = i
+	}
+
+	partyOf := make([]int, len(players))
+	for i := range partyOf {
+		partyOf[i] = -1
+	}
+	for i, party := range parties {
+		if len(party) > teamSize {
+			return nil, ErrPartyTooLarge
+		}
+		for _, id := range party {
+			p, ok := index[id]
+			if !ok {
+				return nil, ErrUnknownPlayer
+			}
+			if partyOf[p] != -1 {
+				return nil, ErrDuplicatePlayer
+			}
+			partyOf[p] = i
+		}
+	}
+
+	var units []unit
+	unitOfParty := make(map[int]int)
+	for i, p := range players {
+		if partyOf[i] == -1 {
+			units = append(units, unit{players: []int{i}, mu: p.Rating.Mu})
+			continue
+		}
+		u, ok := unitOfParty[partyOf[i]]
+		if !ok {
+			u = len(units)
+			unitOfParty[partyOf[i]] = u
+			units = append(units, unit{})
+		}
+		units[u].players = append(units[u].players, i)
+		units[u].mu += p.Rating.Mu
+	}
+	return units, nil
+}
+
+// exact tries every split of the units from u onwards. Teams are interchangeable, so a unit is only placed on an empty
+// team if it is the first empty one.
+func (b *balancer[K]) exact(u int) {
+	if b.err != nil {
+		return
+	}
+	if u == len(b.units) {
+		b.evaluate()
+		return
+	}
+
+	for t := 0; t < b.teams; t++ {
+		if b.sizes[t]+len(b.units[u].players) > b.teamSize {
+			continue
+		}
+		b.assignment[u] = t
+		b.sizes[t] += len(b.units[u].players)
+		b.exact(u + 1)
+		b.sizes[t] -= len(b.units[u].players)
+		if b.sizes[t] == 0 {
+			break
+		}
+	}
+}
+
+// heuristic assigns the largest and strongest units first to the weakest team with room for them, backtracking if
+// the remaining units no longer fit, then swaps units of the same size between teams as long as that improves the
+// balance.
+func (b *balancer[K]) heuristic() {
+	order := make([]int, len(b.units))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, c := b.units[order[i]], b.units[order[j]]
+		if len(a.players) != len(c.players) {
+			return len(a.players) > len(c.players)
+		}
+		return a.mu > c.mu
+	})
+
+	if !b.pack(order, 0, make([]float64, b.teams), make(map[string]bool)) {
+		return
+	}
+
+	b.evaluate()
+	for pass := 0; pass < defaultMaxPasses && b.err == nil; pass++ {
+		improved := false
+		for u := range b.units {
+			for v := u + 1; v < len(b.units); v++ {
+				if b.assignment[u] == b.assignment[v] || len(b.units[u].players) != len(b.units[v].players) {
+					continue
+				}
+				b.assignment[u], b.assignment[v] = b.assignment[v], b.assignment[u]
+				if b.evaluate() {
+					improved = true
+				} else {
+					b.assignment[u], b.assignment[v] = b.assignment[v], b.assignment[u]
+				}
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+// pack assigns the units in order from position i onwards, each to the weakest team with room for it, and backtracks
+// if the remaining units do not fit. Without backtracking a valid split can be missed, e.g. parties of 3, 3, 2, 2 and 2
+// in two teams of 6. Teams with the same number of players are interchangeable for the remaining units, so only the
+// weakest of them is tried, and states that cannot be completed are remembered in failed.
+func (b *balancer[K]) pack(order []int, i int, strength []float64, failed map[string]bool) bool {
+	if i == len(order) {
+		return true
+	}
+	key := packKey(i, b.sizes)
+	if failed[key] {
+		return false
+	}
+
+	u := order[i]
+	size := len(b.units[u].players)
+	candidates := make([]int, 0, b.teams)
+	for t := 0; t < b.teams; t++ {
+		if b.sizes[t]+size <= b.teamSize {
+			candidates = append(candidates, t)
+		}
+	}
+	sort.SliceStable(candidates, func(x, y int) bool {
+		return strength[candidates[x]] < strength[candidates[y]]
+	})
+
+	tried := make(map[int]bool)
+	for _, t := range candidates {
+		if tried[b.sizes[t]] {
+			continue
+		}
+		tried[b.sizes[t]] = true
+
+		b.assignment[u] = t
+		b.sizes[t] += size
+		strength[t] += b.units[u].mu
+		if b.pack(order, i+1, strength, failed) {
+			return true
+		}
+		b.sizes[t] -= size
+		strength[t] -= b.units[u].mu
+	}
+
+	failed[key] = true
+	return false
+}
+
+// packKey identifies a state of pack by the position of the next unit and the sorted team sizes.
+func packKey(i int, sizes []int) string {
+	sorted := append([]int(nil), sizes...)
+	sort.Ints(sorted)
+	return fmt.Sprint(i, sorted)
+}
+
+// evaluate predicts the current assignment and keeps it if it is more balanced than the best so far.
+func (b *balancer[K]) evaluate() bool {
+	teams := make([][]K, b.teams)
+	ratings := make([][]openskill.Rating, b.teams)
+	for t := range teams {
+		teams[t] = make([]K, 0, b.teamSize)
+		ratings[t] = make([]openskill.Rating, 0, b.teamSize)
+	}
+	for i, u := range b.units {
+		t := b.assignment[i]
+		for _, p := range u.players {
+			teams[t] = append(teams[t], b.players[p].ID)
+			ratings[t] = append(ratings[t], b.players[p].Rating)
+		}
+	}
+
+	probabilities, err := b.predictor.ChanceOfWinning(ratings)
+	if err != nil {
+		b.err = err
+		return false
+	}
+
+	highest, lowest := math.Inf(-1), math.Inf(1)
+	for _, p := range probabilities {
+		highest, lowest = math.Max(highest, p), math.Min(lowest, p)
+	}
+	imbalance := highest - lowest
+	if imbalance >= b.bestResult.Imbalance {
+		return false
+	}
+
+	b.best = append(b.best[:0], b.assignment...)
+	b.bestResult = Result[K]{Teams: teams, WinProbabilities: probabilities, Imbalance: imbalance}
+	return true
+}
@@ -0,0 +1,161 @@
+package matchmaking
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func pool(mus ...float64) []Player[string] {
+	players := make([]Player[string], len(mus))
+	for i, mu := range mus {
+		players[i] = Player[string]{ID: string(rune('a' + i)), Rating: openskill.Rating{Mu: mu, Sigma: 5}}
+	}
+	return players
+}
+
+func TestBalance(t *testing.T) {
+	t.Parallel()
+
+	p := openskill.DefaultPredictor()
+
+	t.Run("exact", func(t *testing.T) {
+		result, err := Balance(p, Request[string]{Players: pool(30, 25, 20, 25), Teams: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"a", "c"}, {"b", "d"}}, result.Teams)
+		assert.InDelta(t, 0, result.Imbalance, 1e-9)
+		assert.InDeltaSlice(t, []float64{0.5, 0.5}, result.WinProbabilities, 1e-9)
+	})
+
+	t.Run("parties stay together", func(t *testing.T) {
+		result, err := Balance(p, Request[string]{
+			Players:  pool(30, 25, 20, 25),
+			TeamSize: 2,
+			Parties:  [][]string{{"a", "b"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}}, result.Teams)
+		assert.Greater(t, result.Imbalance, 0.0)
+	})
+
+	t.Run("exact is optimal", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		mus := make([]float64, 8)
+		for i := range mus {
+			mus[i] = 15 + rng.Float64()*20
+		}
+		players := pool(mus...)
+
+		exact, err := Balance(p, Request[string]{Players: players, Teams: 2})
+		assert.NoError(t, err)
+
+		// Every split of 8 players into two teams of 4, with the first player always on the first team.
+		for mask := 0; mask < 1<<8; mask++ {
+			if mask&1 == 0 || popCount(mask) != 4 {
+				continue
+			}
+			var teams [2][]openskill.Rating
+			for i, player := range players {
+				team := 1 - (mask>>i)&1
+				teams[team] = append(teams[team], player.Rating)
+			}
+			probabilities, _ := p.ChanceOfWinning(teams[:])
+			assert.LessOrEqual(t, exact.Imbalance, max(probabilities[0], probabilities[1])-min(probabilities[0], probabilities[1])+1e-12)
+		}
+	})
+
+	t.Run("heuristic", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(3, 4))
+		players := make([]Player[int], 40)
+		for i := range players {
+			players[i] = Player[int]{ID: i, Rating: openskill.Rating{Mu: 10 + rng.Float64()*30, Sigma: 3 + rng.Float64()*5}}
+		}
+		parties := [][]int{{0, 1, 2}, {3, 4}, {5, 6, 7, 8}}
+
+		result, err := Balance(p, Request[int]{Players: players, Teams: 4, Parties: parties})
+
+		assert.NoError(t, err)
+		assert.Less(t, result.Imbalance, 0.05)
+
+		seen := make(map[int]int)
+		for team, ids := range result.Teams {
+			assert.Len(t, ids, 10)
+			for _, id := range ids {
+				seen[id] = team
+			}
+		}
+		assert.Len(t, seen, 40)
+		for _, party := range parties {
+			for _, id := range party {
+				assert.Equal(t, seen[party[0]], seen[id])
+			}
+		}
+	})
+
+	t.Run("heuristic is close to exact", func(t *testing.T) {
+		players := pool(31, 29, 27, 26, 24, 22, 21, 18, 16, 12)
+
+		exact, err := Balance(p, Request[string]{Players: players, Teams: 2})
+		assert.NoError(t, err)
+		heuristic, err := Balance(p, Request[string]{Players: players, Teams: 2, ExactThreshold: -1})
+		assert.NoError(t, err)
+
+		assert.LessOrEqual(t, exact.Imbalance, heuristic.Imbalance)
+		assert.InDelta(t, exact.Imbalance, heuristic.Imbalance, 0.02)
+	})
+
+	t.Run("heuristic backtracks when parties do not fit greedily", func(t *testing.T) {
+		players := pool(30, 28, 26, 24, 22, 20, 18, 16, 14, 12, 10, 8)
+		parties := [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h"}, {"i", "j"}, {"k", "l"}}
+
+		result, err := Balance(p, Request[string]{Players: players, TeamSize: 6, Parties: parties, ExactThreshold: -1})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, [][]string{{"a", "b", "c", "d", "e", "f"}, {"g", "h", "i", "j", "k", "l"}}, result.Teams)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			req Request[string]
+			err error
+		}{
+			{Request[string]{Players: pool(25, 25, 25), Teams: 2}, ErrInvalidTeamShape},
+			{Request[string]{Players: pool(25, 25), Teams: 1}, ErrInvalidTeamShape},
+			{Request[string]{Players: pool(25, 25, 25, 25)}, ErrInvalidTeamShape},
+			{Request[string]{Players: append(pool(25, 25), pool(25, 25)...), Teams: 2}, ErrDuplicatePlayer},
+			{Request[string]{Players: pool(25, 25, 25, 25), Teams: 2, Parties: [][]string{{"a"}, {"a", "b"}}}, ErrDuplicatePlayer},
+			{Request[string]{Players: pool(25, 25, 25, 25), Teams: 2, Parties: [][]string{{"a", "z"}}}, ErrUnknownPlayer},
+			{Request[string]{Players: pool(25, 25, 25, 25), Teams: 2, Parties: [][]string{{"a", "b", "c"}}}, ErrPartyTooLarge},
+		}
+
+		for i, test := range tests {
+			t.Run(fmt.Sprint(i), func(t *testing.T) {
+				_, err := Balance(p, test.req)
+				assert.ErrorIs(t, err, test.err)
+			})
+		}
+	})
+
+	t.Run("no valid partition", func(t *testing.T) {
+		parties := [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h"}}
+
+		_, err := Balance(p, Request[string]{Players: pool(25, 25, 25, 25, 25, 25, 25, 25), Teams: 2, Parties: parties})
+		assert.ErrorIs(t, err, ErrNoValidPartition)
+
+		_, err = Balance(p, Request[string]{Players: pool(25, 25, 25, 25, 25, 25, 25, 25), Teams: 2, Parties: parties, ExactThreshold: -1})
+		assert.ErrorIs(t, err, ErrNoValidPartition)
+	})
+}
+
+func popCount(x int) int {
+	count := 0
+	for ; x > 0; x >>= 1 {
+		count += x & 1
+	}
+	return count
+}
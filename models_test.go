@@ -0,0 +1,22 @@
+package openskill
+
+// allModels lists the constructors of every model, for tests that should hold for all of them.
+var allModels = []struct {
+	defaults    func() Model
+	withOptions func(...Option) (Model, error)
+}{
+	{DefaultPlackettLuceModel, NewPlackettLuceModelWithOptions},
+	{DefaultBradlyTerryFullModel, NewBradlyTerryFullModelWithOptions},
+	{DefaultBradlyTerryPartialModel, NewBradlyTerryPartialModelWithOptions},
+	{DefaultThurstoneMostellerFullModel, NewThurstoneMostellerFullModelWithOptions},
+	{DefaultThurstoneMostellerPartialModel, NewThurstoneMostellerPartialModelWithOptions},
+}
+
+// defaultModels returns every model with its default parameter values.
+func defaultModels() []Model {
+	models := make([]Model, len(allModels))
+	for i, model := range allModels {
+		models[i] = model.defaults()
+	}
+	return models
+}
@@ -0,0 +1,194 @@
+package openskill
+
+import (
+	"fmt"
+	"math"
+)
+
+// Option configures a model created by one of the New...ModelWithOptions constructors.
+type Option func(*options)
+
+// options holds the parameters shared by all models. Models ignore the parameters they do not use.
+type options struct {
+	mu         float64
+	sigma      float64
+	beta       float64
+	kappa      float64
+	tau        float64
+	epsilon    float64
+	margin     float64
+	tolerance  tieTolerance
+	weighting  WeightStrategy
+	decay      DecayCurve
+	limitSigma bool
+	balance    bool
+}
+
+// defaultOptions returns the parameter values used by the Default...Model() constructors.
+func defaultOptions() options {
+	return options{
+		mu:         25.0,
+		sigma:      25.0 / 3.0,
+		beta:       25.0 / 6.0,
+		kappa:      0.0001,
+		tau:        25.0 / 300.0,
+		epsilon:    0.1,
+		weighting:  MinMaxWeights(1, 2),
+		limitSigma: false,
+		balance:    false,
+	}
+}
+
+// WithMu sets the mean of the prior used for new ratings.
+func WithMu(mu float64) Option {
+	return func(o *options) {
+		o.mu = mu
+	}
+}
+
+// WithSigma sets the standard deviation of the prior used for new ratings.
+func WithSigma(sigma float64) Option {
+	return func(o *options) {
+		o.sigma = sigma
+	}
+}
+
+// WithBeta sets the performance variance, i.e. the skill difference needed for an ~80% chance of winning.
+func WithBeta(beta float64) Option {
+	return func(o *options) {
+		o.beta = beta
+	}
+}
+
+// WithKappa sets the lower bound used to keep the variance updates positive.
+func WithKappa(kappa float64) Option {
+	return func(o *options) {
+		o.kappa = kappa
+	}
+}
+
+// WithTau sets the dynamics factor added to a player's sigma before each update, which keeps ratings from freezing
+// as sigma shrinks. Combine it with WithLimitSigma to prevent sigma from growing as a result of a match.
+func WithTau(tau float64) Option {
+	return func(o *options) {
+		o.tau = tau
+	}
+}
+
+// WithEpsilon sets the draw margin used by the Thurstone-Mosteller models.
+func WithEpsilon(epsilon float64) Option {
+	return func(o *options) {
+		o.epsilon = epsilon
+	}
+}
+
+// WithMarginOfVictory scales the mu update of the Plackett-Luce and Bradley-Terry models by the score gap between teams
+// when a match is rated with scores. A gap equal to margin increases the update by ~69% and larger gaps grow logarithmically.
+// A margin of 0, the default, disables margin of victory.
+func WithMarginOfVictory(margin float64) Option {
+	return func(o *options) {
+		o.margin = margin
+	}
+}
+
+// WithAbsoluteTieTolerance treats scores that differ by at most tolerance as a draw when a match is rated with scores.
+func WithAbsoluteTieTolerance(tolerance float64) Option {
+	return func(o *options) {
+		o.tolerance.absolute = tolerance
+	}
+}
+
+// WithRelativeTieTolerance treats scores that differ by at most tolerance times the larger absolute score as a draw
+// when a match is rated with scores, e.g. 0.01 for 1%. It can be combined with an absolute tolerance, in which case the larger applies.
+func WithRelativeTieTolerance(tolerance float64) Option {
+	return func(o *options) {
+		o.tolerance.relative = tolerance
+	}
+}
+
+// WithWeightStrategy sets how the weights of the players in a team are normalized before rating, MinMaxWeights(1, 2) by default.
+func WithWeightStrategy(strategy WeightStrategy) Option {
+	return func(o *options) {
+		o.weighting = strategy
+	}
+}
+
+// WithDecay inflates the sigma of inactive players according to the curve, capped at the model's prior sigma.
+// It is applied by Model.Decay and automatically by Model.RateMatch when the match has a Time and LastPlayed.
+func WithDecay(curve DecayCurve) Option {
+	return func(o *options) {
+		o.decay = curve
+	}
+}
+
+// WithLimitSigma prevents a player's sigma from increasing as a result of a match.
+func WithLimitSigma(limitSigma bool) Option {
+	return func(o *options) {
+		o.limitSigma = limitSigma
+	}
+}
+
+// WithBalance enables weighting players in a team by how far their ordinal is below the best player of the team.
+func WithBalance(balance bool) Option {
+	return func(o *options) {
+		o.balance = balance
+	}
+}
+
+// applyOptions applies the options on top of the default values without validating the result.
+func applyOptions(opts ...Option) options {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// newOptions applies the options on top of the default values and validates the result.
+func newOptions(opts []Option) (options, error) {
+	o := applyOptions(opts...)
+	if err := o.validate(); err != nil {
+		return options{}, err
+	}
+
+	return o, nil
+}
+
+// validate checks that the parameters can be used by the models.
+func (o options) validate() error {
+	if math.IsNaN(o.mu) || math.IsInf(o.mu, 0) {
+		return fmt.Errorf("%w: mu must be finite, got %v", ErrInvalidParameter, o.mu)
+	}
+	if !(o.sigma > 0) || math.IsInf(o.sigma, 0) {
+		return fmt.Errorf("%w: sigma must be positive, got %v", ErrInvalidParameter, o.sigma)
+	}
+	if !(o.beta > 0) || math.IsInf(o.beta, 0) {
+		return fmt.Errorf("%w: beta must be positive, got %v", ErrInvalidParameter, o.beta)
+	}
+	if !(o.kappa > 0) || math.IsInf(o.kappa, 0) {
+		return fmt.Errorf("%w: kappa must be positive, got %v", ErrInvalidParameter, o.kappa)
+	}
+	if !(o.tau >= 0) || math.IsInf(o.tau, 0) {
+		return fmt.Errorf("%w: tau must not be negative, got %v", ErrInvalidParameter, o.tau)
+	}
+	if !(o.epsilon >= 0) || math.IsInf(o.epsilon, 0) {
+		return fmt.Errorf("%w: epsilon must not be negative, got %v", ErrInvalidParameter, o.epsilon)
+	}
+	if !(o.margin >= 0) || math.IsInf(o.margin, 0) {
+		return fmt.Errorf("%w: margin must not be negative, got %v", ErrInvalidParameter, o.margin)
+	}
+	if !(o.tolerance.absolute >= 0) || math.IsInf(o.tolerance.absolute, 0) {
+		return fmt.Errorf("%w: absolute tie tolerance must not be negative, got %v", ErrInvalidParameter, o.tolerance.absolute)
+	}
+	if !(o.tolerance.relative >= 0) || math.IsInf(o.tolerance.relative, 0) {
+		return fmt.Errorf("%w: relative tie tolerance must not be negative, got %v", ErrInvalidParameter, o.tolerance.relative)
+	}
+	if o.weighting == nil {
+		return fmt.Errorf("%w: weight strategy must not be nil", ErrInvalidParameter)
+	}
+	if m, ok := o.weighting.(minMaxWeights); ok && (!(m.min > 0) || !(m.max >= m.min) || math.IsInf(m.max, 0)) {
+		return fmt.Errorf("%w: min max weights must satisfy 0 < min <= max, got [%v, %v]", ErrInvalidParameter, m.min, m.max)
+	}
+
+	return nil
+}
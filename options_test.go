@@ -0,0 +1,85 @@
+package openskill
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewModelWithOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		m, err := NewThurstoneMostellerFullModelWithOptions()
+
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultThurstoneMostellerFullModel(), m)
+	})
+
+	t.Run("all options", func(t *testing.T) {
+		m, err := NewThurstoneMostellerPartialModelWithOptions(
+			WithMu(1500),
+			WithSigma(350),
+			WithBeta(200),
+			WithKappa(0.001),
+			WithTau(5),
+			WithEpsilon(0.2),
+			WithLimitSigma(true),
+			WithBalance(true),
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, NewThurstoneMostellerPartialModel(1500, 350, 200, 0.001, 0.2, 5, true, true), m)
+	})
+
+	t.Run("every model", func(t *testing.T) {
+		for _, model := range allModels {
+			m, err := model.withOptions(WithMu(10), WithSigma(2))
+			assert.NoError(t, err)
+			assert.Equal(t, Rating{Mu: 10, Sigma: 2}, m.NewRating())
+
+			m, err = model.withOptions(WithBeta(0))
+			assert.ErrorIs(t, err, ErrInvalidParameter)
+			assert.Nil(t, m)
+		}
+	})
+}
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Option{
+		"infinite mu":                     WithMu(math.Inf(1)),
+		"nan mu":                          WithMu(math.NaN()),
+		"zero sigma":                      WithSigma(0),
+		"negative sigma":                  WithSigma(-1),
+		"zero beta":                       WithBeta(0),
+		"negative beta":                   WithBeta(-1),
+		"zero kappa":                      WithKappa(0),
+		"negative tau":                    WithTau(-0.1),
+		"nan tau":                         WithTau(math.NaN()),
+		"negative epsilon":                WithEpsilon(-0.1),
+		"negative margin":                 WithMarginOfVictory(-1),
+		"negative absolute tie tolerance": WithAbsoluteTieTolerance(-1),
+		"negative relative tie tolerance": WithRelativeTieTolerance(-0.01),
+		"nil weight strategy":             WithWeightStrategy(nil),
+		"zero min weight":                 WithWeightStrategy(MinMaxWeights(0, 1)),
+		"max weight below min":            WithWeightStrategy(MinMaxWeights(2, 1)),
+		"infinite max weight":             WithWeightStrategy(MinMaxWeights(1, math.Inf(1))),
+	}
+
+	for name, opt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newOptions([]Option{opt})
+
+			assert.ErrorIs(t, err, ErrInvalidParameter)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		_, err := newOptions([]Option{WithMu(-5), WithTau(0), WithEpsilon(0)})
+
+		assert.NoError(t, err)
+	})
+}
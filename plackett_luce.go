@@ -3,43 +3,105 @@ package openskill
 import (
 	"math"
 	"sort"
+	"time"
 )
 
 type PlackettLuceModel struct {
+	PlackettLucePredictor
 	mu         float64
 	sigma      float64
-	beta       float64
-	kappa      float64
+	tau        float64
+	margin     float64
+	tolerance  tieTolerance
+	weighting  WeightStrategy
+	decay      DecayCurve
 	limitSigma bool
-	balance    bool
 }
 
-func DefaultPlackettLuceModel() Rater {
-	return PlackettLuceModel{
-		mu:         25.0,
-		sigma:      25.0 / 3.0,
-		beta:       25.0 / 6.0,
-		kappa:      0.0001,
-		limitSigma: false,
-		balance:    false,
+// DefaultPlackettLuceModel returns a new PlackettLuceModel with sensible default parameter values.
+func DefaultPlackettLuceModel() Model {
+	return newPlackettLuceModel(defaultOptions())
+}
+
+// NewPlackettLuceModel returns a new PlackettLuceModel with custom parameter values and no tau.
+// The parameters are not validated, use NewPlackettLuceModelWithOptions to reject invalid values or to set tau.
+func NewPlackettLuceModel(mu, sigma, beta, kappa float64, limitSigma, balance bool) Model {
+	return newPlackettLuceModel(applyOptions(
+		WithMu(mu),
+		WithSigma(sigma),
+		WithBeta(beta),
+		WithKappa(kappa),
+		WithTau(0),
+		WithLimitSigma(limitSigma),
+		WithBalance(balance),
+	))
+}
+
+// NewPlackettLuceModelWithOptions returns a new PlackettLuceModel configured by the options on top of the default parameter values.
+// An error wrapping ErrInvalidParameter is returned if any of the resulting parameters are invalid.
+// Epsilon is ignored by this model.
+func NewPlackettLuceModelWithOptions(opts ...Option) (Model, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
 	}
+
+	return newPlackettLuceModel(o), nil
 }
 
-func NewPlackettLuceModel(mu, sigma, beta, kappa float64, limitSigma, balance bool) Rater {
+func newPlackettLuceModel(o options) PlackettLuceModel {
 	return PlackettLuceModel{
-		mu:         mu,
-		sigma:      sigma,
-		beta:       beta,
-		kappa:      kappa,
-		limitSigma: limitSigma,
-		balance:    balance,
+		PlackettLucePredictor: NewPlackettLucePredictor(o.beta, o.kappa, o.balance),
+		mu:                    o.mu,
+		sigma:                 o.sigma,
+		tau:                   o.tau,
+		margin:                o.margin,
+		tolerance:             o.tolerance,
+		weighting:             o.weighting,
+		decay:                 o.decay,
+		limitSigma:            o.limitSigma,
 	}
 }
 
+// NewRating returns a new rating from the model's mu and sigma unless overridden.
+func (p PlackettLuceModel) NewRating(opts ...RatingOption) Rating {
+	return newRating(p.mu, p.sigma, opts)
+}
+
+// Decay returns the rating with its sigma inflated by the model's decay curve for the time between lastPlayed and now,
+// capped at the model's prior sigma. The rating is returned unchanged if the model has no decay curve.
+func (p PlackettLuceModel) Decay(rating Rating, lastPlayed, now time.Time) Rating {
+	return InflateSigma(rating, lastPlayed, now, p.decay, p.sigma)
+}
+
+// Ranking returns the ranks the model rates the outcome with, where scores within the model's tie tolerance tie.
+func (p PlackettLuceModel) Ranking(outcome Outcome) []int {
+	return outcome.ranking(p.tolerance)
+}
+
+// Rate updates the ratings of the teams based on either their ranks or scores, optionally weighted per player.
 func (p PlackettLuceModel) Rate(teams [][]Rating, ranks, scores []int, weights [][]float64) ([][]Rating, error) {
+	return p.rate(teams, ranks, intsToFloats(scores), weights, nil)
+}
+
+// RateMatch updates the ratings of the players in the match based on its outcome.
+// If the model has a decay curve, the sigma of each player is first inflated for the time since they last played.
+func (p PlackettLuceModel) RateMatch(match Match) ([][]Rating, error) {
+	teams, err := decayTeams(match, p.decay, p.sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.rate(teams, match.Outcome.Ranks(), match.Outcome.Scores(), match.Weights, match.PartialPlay)
+}
+
+func (p PlackettLuceModel) rate(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) ([][]Rating, error) {
 	if err := checkRateParameters(teams, ranks, scores, weights); err != nil {
 		return nil, err
 	}
+	if err := checkPartialPlay(teams, partialPlay); err != nil {
+		return nil, err
+	}
 
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
@@ -57,36 +119,44 @@ func (p PlackettLuceModel) Rate(teams [][]Rating, ranks, scores []int, weights [
 
 	for teamIndex, team := range teamsCopy {
 		for playerIndex, player := range team {
-			teamsCopy[teamIndex][playerIndex].Sigma = player.Sigma
+			teamsCopy[teamIndex][playerIndex].Sigma = math.Sqrt(player.Sigma*player.Sigma + p.tau*p.tau)
 		}
 	}
 
 	if scores != nil {
-		ranks = make([]int, len(scores))
-		for i, score := range scores {
-			ranks[i] = int(-score)
-		}
+		ranks = scoresToRanks(scores, p.tolerance)
 	}
 
-	for i := range weights {
-		weights[i] = normalize(weights[i], 1, 2)
+	weights, err := normalizeWeights(weights, p.weighting)
+	if err != nil {
+		return nil, err
 	}
 
 	var tenet []int
+	sortedPartialPlay := partialPlay
 	var orderedTeams [][]Rating
 
 	if ranks != nil {
 		orderedTeams, tenet = unwind(ranks, teamsCopy)
 		teamsCopy = orderedTeams
+		if scores != nil {
+			scores, _ = unwind(ranks, scores)
+		}
+		if weights != nil {
+			weights, _ = unwind(ranks, weights)
+		}
+		if partialPlay != nil {
+			sortedPartialPlay, _ = unwind(ranks, partialPlay)
+		}
 		sort.Ints(ranks)
 	}
 
 	var result [][]Rating
 	if ranks != nil && tenet != nil {
-		result = p.compute(teamsCopy, ranks, weights)
+		result = p.compute(teamsCopy, ranks, scores, weights, sortedPartialPlay)
 		result, _ = unwind(tenet, result)
 	} else {
-		result = p.compute(teamsCopy, nil, weights)
+		result = p.compute(teamsCopy, nil, nil, weights, sortedPartialPlay)
 	}
 
 	finalResult := make([][]Rating, len(result))
@@ -97,6 +167,10 @@ func (p PlackettLuceModel) Rate(teams [][]Rating, ranks, scores []int, weights [
 		}
 	}
 
+	if partialPlay != nil {
+		finalResult = applyPartialPlay(teams, finalResult, partialPlay)
+	}
+
 	if p.limitSigma {
 		for teamIndex, team := range finalResult {
 			for playerIndex, player := range team {
@@ -108,14 +182,14 @@ func (p PlackettLuceModel) Rate(teams [][]Rating, ranks, scores []int, weights [
 	return finalResult, nil
 }
 
-func (p PlackettLuceModel) compute(teams [][]Rating, ranks []int, weights [][]float64) [][]Rating {
+func (p PlackettLuceModel) compute(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) [][]Rating {
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
 		originalTeams[i] = make([]Rating, len(teams[i]))
 		copy(originalTeams[i], teams[i])
 	}
 
-	teamRatings := calculateTeamRatings(teams, ranks, p.balance, p.kappa)
+	teamRatings := calculatePartialTeamRatings(teams, ranks, partialPlay, p.balance, p.kappa)
 	a := a(teamRatings)
 	c := c(teamRatings, p.beta)
 	sumQ := sumQ(teamRatings, c)
@@ -139,6 +213,9 @@ func (p PlackettLuceModel) compute(teams [][]Rating, ranks []int, weights [][]fl
 		}
 
 		omega *= t1.SigmaSquared / c
+		if scores != nil {
+			omega *= meanMarginFactor(scores, i, p.margin)
+		}
 		delta *= t1.SigmaSquared / math.Pow(c, 2)
 		gammaValue := math.Sqrt(p.sigma*p.sigma) / c
 		delta *= gammaValue
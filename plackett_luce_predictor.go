@@ -0,0 +1,231 @@
+package openskill
+
+import (
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"sort"
+)
+
+// maxExactTiedTeams is the largest number of teams sharing a rank whose probability is computed exactly by
+// PlackettLucePredictor.ChanceOfRanking. The exact computation takes time and memory exponential in this number.
+const maxExactTiedTeams = 16
+
+// PlackettLucePredictor predicts matches with the Plackett-Luce likelihood that PlackettLuceModel rates with, where
+// a team finishes ahead of the remaining teams with a probability proportional to exp(mu / c).
+// It falls back to the default predictor for draws and match quality, which the likelihood does not cover.
+type PlackettLucePredictor struct {
+	predictor
+	samples int
+	seed    uint64
+}
+
+// DefaultPlackettLucePredictor returns a new PlackettLucePredictor with sensible default parameter values.
+func DefaultPlackettLucePredictor() PlackettLucePredictor {
+	return NewPlackettLucePredictor(25.0/6.0, 0.0001, false)
+}
+
+// NewPlackettLucePredictor returns a new PlackettLucePredictor with custom parameter values.
+func NewPlackettLucePredictor(beta, kappa float64, balance bool) PlackettLucePredictor {
+	return PlackettLucePredictor{
+		predictor: predictor{
+			beta:    beta,
+			kappa:   kappa,
+			balance: balance,
+		},
+		samples: 10000,
+		seed:    1,
+	}
+}
+
+// WithSampling returns a copy of the predictor that estimates the probability of more than 16 teams sharing a rank
+// from the given number of samples, drawn from a random number generator seeded with seed. ChanceOfRanking returns
+// ErrInvalidSampleCount if it needs to sample and samples is not positive.
+func (p PlackettLucePredictor) WithSampling(samples int, seed uint64) PlackettLucePredictor {
+	p.samples = samples
+	p.seed = seed
+	return p
+}
+
+// ChanceOfWinning returns the probability of each team finishing first as a number between 0 and 1.
+func (p PlackettLucePredictor) ChanceOfWinning(teams [][]Rating) ([]float64, error) {
+	if err := checkTeams(teams); err != nil {
+		return nil, err
+	}
+
+	strengths := p.strengths(teams)
+
+	total := 0.0
+	for _, s := range strengths {
+		total += s
+	}
+
+	result := make([]float64, len(teams))
+	for i, s := range strengths {
+		result[i] = s / total
+	}
+	return result, nil
+}
+
+// ChanceOfRanks returns the most likely ranking of the teams, which orders them by their mu, and the probability of
+// each team finishing first.
+func (p PlackettLucePredictor) ChanceOfRanks(teams [][]Rating) ([]int, []float64, error) {
+	probabilities, err := p.ChanceOfWinning(teams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ranksFromProbabilities(probabilities), probabilities, nil
+}
+
+// ChanceOfRanking returns the probability of the teams finishing with the given ranks, where a lower rank is better.
+// Teams sharing a rank may finish in any order among themselves.
+func (p PlackettLucePredictor) ChanceOfRanking(teams [][]Rating, ranks []int) (float64, error) {
+	if err := checkTeams(teams); err != nil {
+		return 0, err
+	}
+	if len(ranks) != len(teams) {
+		return 0, ErrRanksAndTeamsMismatch
+	}
+
+	strengths := p.strengths(teams)
+
+	order := make([]int, len(teams))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return ranks[order[i]] < ranks[order[j]]
+	})
+
+	// remaining[i] is the total strength of the teams from position i onwards.
+	remaining := make([]float64, len(order)+1)
+	for i := len(order) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + strengths[order[i]]
+	}
+
+	var rng *rand.Rand
+	probability := 1.0
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && ranks[order[end]] == ranks[order[start]] {
+			end++
+		}
+
+		group := make([]float64, 0, end-start)
+		for _, team := range order[start:end] {
+			group = append(group, strengths[team])
+		}
+
+		switch {
+		case len(group) == 1:
+			probability *= group[0] / remaining[start]
+		case len(group) <= maxExactTiedTeams:
+			probability *= chanceOfGroupExact(group, remaining[start])
+		default:
+			if p.samples <= 0 {
+				return 0, ErrInvalidSampleCount
+			}
+			if rng == nil {
+				rng = rand.New(rand.NewPCG(p.seed, p.seed))
+			}
+			probability *= chanceOfGroupSampled(group, remaining[start], p.samples, rng)
+		}
+		start = end
+	}
+
+	return probability, nil
+}
+
+// RankDistribution estimates the probability of each team finishing in each position by sampling orders from the
+// Plackett-Luce distribution. The same seed always gives the same result.
+func (p PlackettLucePredictor) RankDistribution(teams [][]Rating, samples int, seed uint64) (RankDistribution, error) {
+	if err := checkTeams(teams); err != nil {
+		return RankDistribution{}, err
+	}
+	if samples <= 0 {
+		return RankDistribution{}, ErrInvalidSampleCount
+	}
+
+	logStrengths := p.strengths(teams)
+	for i, s := range logStrengths {
+		logStrengths[i] = math.Log(s)
+	}
+
+	// Sorting by log strength plus Gumbel noise draws an order from the Plackett-Luce distribution.
+	return sampleRankDistribution(len(teams), samples, seed, func(rng *rand.Rand, performances []float64) {
+		for i, s := range logStrengths {
+			performances[i] = s - math.Log(-math.Log(1-rng.Float64()))
+		}
+	}), nil
+}
+
+// strengths returns exp(mu / c) for each team, scaled so the strongest team has strength 1.
+func (p PlackettLucePredictor) strengths(teams [][]Rating) []float64 {
+	teamRatings := calculateTeamRatings(teams, nil, p.balance, p.kappa)
+	c := c(teamRatings, p.beta)
+
+	maxMu := math.Inf(-1)
+	for _, t := range teamRatings {
+		maxMu = math.Max(maxMu, t.Mu)
+	}
+
+	result := make([]float64, len(teamRatings))
+	for i, t := range teamRatings {
+		result[i] = math.Exp((t.Mu - maxMu) / c)
+	}
+	return result
+}
+
+// chanceOfGroupExact returns the probability that the teams with the given strengths are picked first, in any order,
+// from remaining teams with the given total strength. f[set] is the probability that the set is picked first.
+func chanceOfGroupExact(strengths []float64, total float64) float64 {
+	n := len(strengths)
+	f := make([]float64, 1<<n)
+	sum := make([]float64, 1<<n)
+	f[0] = 1
+
+	for set := 1; set < len(f); set++ {
+		sum[set] = sum[set&(set-1)] + strengths[bits.TrailingZeros(uint(set))]
+		for i := 0; i < n; i++ {
+			if set&(1<<i) == 0 {
+				continue
+			}
+			previous := set ^ (1 << i)
+			f[set] += f[previous] * strengths[i] / (total - sum[previous])
+		}
+	}
+	return f[len(f)-1]
+}
+
+// chanceOfGroupSampled estimates chanceOfGroupExact by sampling orders of the group from the Plackett-Luce
+// distribution restricted to the group and weighting each by its likelihood ratio.
+func chanceOfGroupSampled(strengths []float64, total float64, samples int, rng *rand.Rand) float64 {
+	groupTotal := 0.0
+	for _, s := range strengths {
+		groupTotal += s
+	}
+
+	keys := make([]float64, len(strengths))
+	order := make([]int, len(strengths))
+	estimate := 0.0
+	for range samples {
+		// Sorting by log strength plus Gumbel noise draws an order from the Plackett-Luce distribution.
+		for i, s := range strengths {
+			keys[i] = math.Log(s) - math.Log(-math.Log(1-rng.Float64()))
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool {
+			return keys[order[i]] > keys[order[j]]
+		})
+
+		ratio := 1.0
+		picked := 0.0
+		for _, i := range order {
+			ratio *= (groupTotal - picked) / (total - picked)
+			picked += strengths[i]
+		}
+		estimate += ratio
+	}
+	return estimate / float64(samples)
+}
@@ -0,0 +1,138 @@
+package openskill
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// permutations returns every order of 0..n-1.
+func permutations(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	var result [][]int
+	for _, p := range permutations(n - 1) {
+		for i := 0; i <= len(p); i++ {
+			q := append(append(append([]int{}, p[:i]...), n-1), p[i:]...)
+			result = append(result, q)
+		}
+	}
+	return result
+}
+
+func TestPlackettLucePredictor(t *testing.T) {
+	t.Parallel()
+
+	p := DefaultPlackettLucePredictor()
+	teams := [][]Rating{{{30, 3}}, {{25, 5}, {5, 2}}, {{20, 4}}, {{28, 8}}}
+
+	t.Run("full rankings sum to one", func(t *testing.T) {
+		winning, err := p.ChanceOfWinning(teams)
+		assert.NoError(t, err)
+
+		total := 0.0
+		first := make([]float64, len(teams))
+		for _, order := range permutations(len(teams)) {
+			ranks := make([]int, len(teams))
+			for position, team := range order {
+				ranks[team] = position + 1
+			}
+			probability, err := p.ChanceOfRanking(teams, ranks)
+			assert.NoError(t, err)
+
+			total += probability
+			first[order[0]] += probability
+		}
+
+		assert.InDelta(t, 1, total, 1e-12)
+		assert.InDeltaSlice(t, winning, first, 1e-12)
+	})
+
+	t.Run("two teams", func(t *testing.T) {
+		teams := [][]Rating{{{30, 3}}, {{20, 4}}}
+		c := math.Sqrt(9 + 16 + 2*(25.0/6.0)*(25.0/6.0))
+		expected := math.Exp(30/c) / (math.Exp(30/c) + math.Exp(20/c))
+
+		probability, err := p.ChanceOfRanking(teams, []int{1, 2})
+
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, probability, 1e-12)
+	})
+
+	t.Run("ties cover every order", func(t *testing.T) {
+		tied, err := p.ChanceOfRanking(teams, []int{2, 1, 2, 3})
+		assert.NoError(t, err)
+
+		a, _ := p.ChanceOfRanking(teams, []int{2, 1, 3, 4})
+		b, _ := p.ChanceOfRanking(teams, []int{3, 1, 2, 4})
+
+		assert.InDelta(t, a+b, tied, 1e-12)
+
+		everyone, err := p.ChanceOfRanking(teams, []int{1, 1, 1, 1})
+		assert.NoError(t, err)
+		assert.InDelta(t, 1, everyone, 1e-12)
+	})
+
+	t.Run("most likely ranking", func(t *testing.T) {
+		ranks, probabilities, err := p.ChanceOfRanks([][]Rating{{{20, 3}}, {{30, 3}}, {{20, 3}}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2, 1, 2}, ranks)
+		assert.InDelta(t, 1, probabilities[0]+probabilities[1]+probabilities[2], 1e-12)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := p.ChanceOfRanking(teams, []int{1, 2})
+		assert.ErrorIs(t, err, ErrRanksAndTeamsMismatch)
+
+		_, err = p.ChanceOfRanking([][]Rating{{{25, 3}}}, []int{1})
+		assert.ErrorIs(t, err, ErrLessThanTwoTeams)
+
+		_, err = p.ChanceOfWinning([][]Rating{{{25, 3}}, {}})
+		assert.ErrorIs(t, err, ErrEmptyTeam)
+	})
+
+	t.Run("large tied groups are sampled", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(5, 6))
+		teams := make([][]Rating, 30)
+		ranks := make([]int, 30)
+		for i := range teams {
+			teams[i] = []Rating{{10 + rng.Float64()*30, 3}}
+			ranks[i] = 1 + i/20
+		}
+
+		first, err := p.ChanceOfRanking(teams, ranks)
+		assert.NoError(t, err)
+		again, err := p.ChanceOfRanking(teams, ranks)
+		assert.NoError(t, err)
+		other, err := p.WithSampling(10000, 2).ChanceOfRanking(teams, ranks)
+		assert.NoError(t, err)
+
+		assert.Equal(t, first, again)
+		assert.Greater(t, first, 0.0)
+		assert.Less(t, first, 1.0)
+		assert.InEpsilon(t, first, other, 0.05)
+
+		_, err = p.WithSampling(0, 1).ChanceOfRanking(teams, ranks)
+		assert.ErrorIs(t, err, ErrInvalidSampleCount)
+
+		_, err = p.WithSampling(0, 1).ChanceOfRanking(teams[:16], ranks[:16])
+		assert.NoError(t, err)
+	})
+}
+
+func TestChanceOfGroupSampled(t *testing.T) {
+	t.Parallel()
+
+	strengths := []float64{1, 0.5, 0.25, 0.8, 0.1}
+	total := 4.0
+
+	exact := chanceOfGroupExact(strengths, total)
+	sampled := chanceOfGroupSampled(strengths, total, 20000, rand.New(rand.NewPCG(1, 1)))
+
+	assert.InEpsilon(t, exact, sampled, 0.02)
+	assert.InDelta(t, 1, chanceOfGroupExact(strengths, 2.65), 1e-12)
+}
@@ -0,0 +1,51 @@
+package openskill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlackettLuceTau(t *testing.T) {
+	t.Parallel()
+
+	teams := [][]Rating{{{25, 1}}, {{25, 1}}}
+
+	t.Run("default", func(t *testing.T) {
+		m := DefaultPlackettLuceModel()
+
+		assert.Equal(t, 25.0/300.0, m.(PlackettLuceModel).tau)
+	})
+
+	t.Run("tau inflates sigma", func(t *testing.T) {
+		withoutTau, err := NewPlackettLuceModelWithOptions(WithTau(0))
+		assert.NoError(t, err)
+		withTau, err := NewPlackettLuceModelWithOptions(WithTau(1))
+		assert.NoError(t, err)
+
+		without, err := withoutTau.Rate(teams, []int{1, 2}, nil, nil)
+		assert.NoError(t, err)
+		with, err := withTau.Rate(teams, []int{1, 2}, nil, nil)
+		assert.NoError(t, err)
+
+		assert.Greater(t, with[0][0].Sigma, without[0][0].Sigma)
+		assert.Greater(t, with[0][0].Sigma, teams[0][0].Sigma)
+	})
+
+	t.Run("limit sigma", func(t *testing.T) {
+		m, err := NewPlackettLuceModelWithOptions(WithTau(1), WithLimitSigma(true))
+		assert.NoError(t, err)
+
+		result, err := m.Rate(teams, []int{1, 2}, nil, nil)
+		assert.NoError(t, err)
+
+		assert.LessOrEqual(t, result[0][0].Sigma, teams[0][0].Sigma)
+		assert.LessOrEqual(t, result[1][0].Sigma, teams[1][0].Sigma)
+	})
+
+	t.Run("positional constructor", func(t *testing.T) {
+		m := NewPlackettLuceModel(25, 25.0/3.0, 25.0/6.0, 0.0001, false, false)
+
+		assert.Equal(t, 0.0, m.(PlackettLuceModel).tau)
+	})
+}
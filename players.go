@@ -0,0 +1,124 @@
+package openskill
+
+import (
+	"fmt"
+	"time"
+)
+
+// PlayerMatch describes a match between teams of players identified by keys of type K.
+type PlayerMatch[K comparable] struct {
+	// ID optionally identifies the match.
+	ID string
+	// Time optionally records when the match was played.
+	Time time.Time
+	// Teams are the keys of the players of each team.
+	Teams [][]K
+	// Outcome is the result of the match.
+	Outcome Outcome
+	// Weights optionally describe the contribution of each player to their team.
+	Weights [][]float64
+	// PartialPlay optionally describes each player's share of participation in the match, see Match.PartialPlay.
+	PartialPlay [][]float64
+	// LastPlayed optionally records when each player last played before the match, see Match.LastPlayed.
+	LastPlayed map[K]time.Time
+}
+
+// Players returns the keys of all players in the match in team order.
+func (m PlayerMatch[K]) Players() []K {
+	var players []K
+	for _, team := range m.Teams {
+		players = append(players, team...)
+	}
+	return players
+}
+
+// Resolve returns the match with the ratings of the players looked up in ratings.
+// Players missing from ratings get a rating from newRating, or ErrUnknownPlayer is returned if newRating is nil.
+// ErrDuplicatePlayer is returned if a player appears more than once in the match.
+func (m PlayerMatch[K]) Resolve(ratings map[K]Rating, newRating func() Rating) (Match, error) {
+	seen := make(map[K]bool)
+	teams := make([][]Rating, len(m.Teams))
+	var lastPlayed [][]time.Time
+	if m.LastPlayed != nil {
+		lastPlayed = make([][]time.Time, len(m.Teams))
+	}
+	for i, team := range m.Teams {
+		teams[i] = make([]Rating, len(team))
+		if lastPlayed != nil {
+			lastPlayed[i] = make([]time.Time, len(team))
+		}
+		for j, player := range team {
+			if seen[player] {
+				return Match{}, fmt.Errorf("%w: %v", ErrDuplicatePlayer, player)
+			}
+			seen[player] = true
+
+			rating, ok := ratings[player]
+			if !ok {
+				if newRating == nil {
+					return Match{}, fmt.Errorf("%w: %v", ErrUnknownPlayer, player)
+				}
+				rating = newRating()
+			}
+			teams[i][j] = rating
+			if lastPlayed != nil {
+				lastPlayed[i][j] = m.LastPlayed[player]
+			}
+		}
+	}
+
+	return Match{
+		ID:          m.ID,
+		Time:        m.Time,
+		Teams:       teams,
+		Outcome:     m.Outcome,
+		Weights:     m.Weights,
+		PartialPlay: m.PartialPlay,
+		LastPlayed:  lastPlayed,
+	}, nil
+}
+
+// RatePlayers rates a match between identified players and returns the updated rating of every player in the match.
+// The current ratings are looked up in ratings, which is not modified.
+// If r is a Model, players missing from ratings start from the model's prior, otherwise ErrUnknownPlayer is returned.
+func RatePlayers[K comparable](r Rater, match PlayerMatch[K], ratings map[K]Rating) (map[K]Rating, error) {
+	_, after, err := ratePlayers(r, match, ratings)
+	return after, err
+}
+
+// ratePlayers rates a match between identified players and returns the rating of every player in the match before and after it.
+func ratePlayers[K comparable](r Rater, match PlayerMatch[K], ratings map[K]Rating) (map[K]Rating, map[K]Rating, error) {
+	var newRating func() Rating
+	model, isModel := r.(Model)
+	if isModel {
+		newRating = func() Rating {
+			return model.NewRating()
+		}
+	}
+
+	resolved, err := match.Resolve(ratings, newRating)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updatedTeams [][]Rating
+	if isModel {
+		updatedTeams, err = model.RateMatch(resolved)
+	} else {
+		updatedTeams, err = rateMatch(r, resolved)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	before := make(map[K]Rating)
+	after := make(map[K]Rating)
+	for i, team := range match.Teams {
+		for j, player := range team {
+			before[player] = resolved.Teams[i][j]
+			after[player] = updatedTeams[i][j]
+		}
+	}
+
+	return before, after, nil
+}
@@ -0,0 +1,162 @@
+package openskill
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// identityRater returns the ratings unchanged and is used to test code built on top of a plain Rater.
+type identityRater struct{}
+
+func (identityRater) Rate(teams [][]Rating, ranks, scores []int, weights [][]float64) ([][]Rating, error) {
+	if err := checkRateParameters(teams, ranks, intsToFloats(scores), weights); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func TestRatePlayers(t *testing.T) {
+	t.Parallel()
+
+	models := []Model{
+		DefaultPlackettLuceModel(),
+		DefaultBradlyTerryFullModel(),
+		DefaultBradlyTerryPartialModel(),
+		DefaultThurstoneMostellerFullModel(),
+		DefaultThurstoneMostellerPartialModel(),
+	}
+
+	ratings := map[string]Rating{
+		"alice": {Mu: 30, Sigma: 5},
+		"bob":   {Mu: 20, Sigma: 4},
+		"carol": {Mu: 25, Sigma: 3},
+	}
+
+	t.Run("matches positional rating", func(t *testing.T) {
+		match := PlayerMatch[string]{
+			Teams:   [][]string{{"alice"}, {"bob", "carol"}},
+			Outcome: Ranks(1, 2),
+		}
+
+		for _, m := range models {
+			expected, err := m.Rate([][]Rating{{ratings["alice"]}, {ratings["bob"], ratings["carol"]}}, []int{1, 2}, nil, nil)
+			assert.NoError(t, err)
+
+			actual, err := RatePlayers(m, match, ratings)
+			assert.NoError(t, err)
+
+			assert.Equal(t, map[string]Rating{
+				"alice": expected[0][0],
+				"bob":   expected[1][0],
+				"carol": expected[1][1],
+			}, actual)
+		}
+	})
+
+	t.Run("ratings are not modified", func(t *testing.T) {
+		match := PlayerMatch[string]{
+			Teams:   [][]string{{"alice"}, {"bob"}},
+			Outcome: Scores(3, 1),
+		}
+
+		_, err := RatePlayers(DefaultThurstoneMostellerFullModel(), match, ratings)
+		assert.NoError(t, err)
+
+		assert.Equal(t, Rating{Mu: 30, Sigma: 5}, ratings["alice"])
+		assert.Equal(t, Rating{Mu: 20, Sigma: 4}, ratings["bob"])
+	})
+
+	t.Run("new player gets model prior", func(t *testing.T) {
+		m := DefaultThurstoneMostellerFullModel()
+		match := PlayerMatch[int]{
+			Teams:   [][]int{{1}, {2}},
+			Outcome: Ranks(1, 2),
+		}
+
+		expected, err := m.Rate([][]Rating{{{Mu: 10, Sigma: 1}}, {m.NewRating()}}, []int{1, 2}, nil, nil)
+		assert.NoError(t, err)
+
+		actual, err := RatePlayers(m, match, map[int]Rating{1: {Mu: 10, Sigma: 1}})
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[int]Rating{1: expected[0][0], 2: expected[1][0]}, actual)
+	})
+
+	t.Run("unknown player without model", func(t *testing.T) {
+		match := PlayerMatch[string]{
+			Teams:   [][]string{{"alice"}, {"dave"}},
+			Outcome: Ranks(1, 2),
+		}
+
+		_, err := RatePlayers(identityRater{}, match, ratings)
+
+		assert.ErrorIs(t, err, ErrUnknownPlayer)
+	})
+
+	t.Run("plain rater", func(t *testing.T) {
+		match := PlayerMatch[string]{
+			Teams:   [][]string{{"alice"}, {"bob"}},
+			Outcome: Ranks(1, 2),
+		}
+
+		actual, err := RatePlayers(identityRater{}, match, ratings)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]Rating{"alice": ratings["alice"], "bob": ratings["bob"]}, actual)
+	})
+
+	t.Run("duplicate player", func(t *testing.T) {
+		match := PlayerMatch[string]{
+			Teams:   [][]string{{"alice"}, {"bob", "alice"}},
+			Outcome: Ranks(1, 2),
+		}
+
+		_, err := RatePlayers(DefaultPlackettLuceModel(), match, ratings)
+
+		assert.ErrorIs(t, err, ErrDuplicatePlayer)
+	})
+
+	t.Run("invalid match", func(t *testing.T) {
+		match := PlayerMatch[string]{
+			Teams: [][]string{{"alice"}, {"bob"}},
+		}
+
+		_, err := RatePlayers(DefaultPlackettLuceModel(), match, ratings)
+
+		assert.ErrorIs(t, err, ErrNoRanksOrScores)
+	})
+}
+
+func TestPlayerMatchPlayers(t *testing.T) {
+	t.Parallel()
+
+	match := PlayerMatch[string]{Teams: [][]string{{"a"}, {"b", "c"}}}
+
+	assert.Equal(t, []string{"a", "b", "c"}, match.Players())
+}
+
+func TestRatePlayersDecay(t *testing.T) {
+	t.Parallel()
+
+	lastPlayed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m, err := NewThurstoneMostellerFullModelWithOptions(WithDecay(LinearDecay(1, 24*time.Hour)))
+	assert.NoError(t, err)
+
+	ratings := map[string]Rating{"alice": {Mu: 30, Sigma: 2}, "bob": {Mu: 20, Sigma: 2}}
+	match := PlayerMatch[string]{
+		Time:       lastPlayed.Add(48 * time.Hour),
+		Teams:      [][]string{{"alice"}, {"bob"}},
+		Outcome:    Ranks(1, 2),
+		LastPlayed: map[string]time.Time{"alice": lastPlayed},
+	}
+
+	expected, err := m.Rate([][]Rating{{{Mu: 30, Sigma: 4}}, {{Mu: 20, Sigma: 2}}}, []int{1, 2}, nil, nil)
+	assert.NoError(t, err)
+
+	actual, err := RatePlayers(m, match, ratings)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]Rating{"alice": expected[0][0], "bob": expected[1][0]}, actual)
+}
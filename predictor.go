@@ -11,6 +11,13 @@ type Predictor interface {
 	ChanceOfRanks(teams [][]Rating) ([]int, []float64, error)
 }
 
+// QualityPredictor is a Predictor that can also rate how fair a match is. All predictors and models in this package
+// implement it, including the Predictor returned by DefaultPredictor and NewPredictor.
+type QualityPredictor interface {
+	Predictor
+	MatchQuality(teams [][]Rating) (float64, error)
+}
+
 type predictor struct {
 	beta    float64
 	kappa   float64
@@ -172,8 +179,37 @@ func (p predictor) ChanceOfRanks(teams [][]Rating) ([]int, []float64, error) {
 		normalizedProbabilities[i] = p / totalProbability
 	}
 
+	return ranksFromProbabilities(normalizedProbabilities), normalizedProbabilities, nil
+}
+
+// pairwiseChanceOfWinning returns the chance of each team winning as its average chance of beating each other team,
+// normalized to sum to 1. beats returns the chance of a team beating another given the combined deviation c.
+func pairwiseChanceOfWinning(teamRatings []teamRating, beta float64, beats func(a, b teamRating, c float64) float64) []float64 {
+	n := len(teamRatings)
+	probabilities := make([]float64, n)
+	total := 0.0
+	for i, a := range teamRatings {
+		for j, b := range teamRatings {
+			if i != j {
+				c := math.Sqrt(2*beta*beta + a.SigmaSquared + b.SigmaSquared)
+				probabilities[i] += beats(a, b, c)
+			}
+		}
+		probabilities[i] /= float64(n - 1)
+		total += probabilities[i]
+	}
+
+	for i := range probabilities {
+		probabilities[i] /= total
+	}
+	return probabilities
+}
+
+// ranksFromProbabilities ranks teams by their chance of winning, where teams with practically equal chances share a rank.
+func ranksFromProbabilities(probabilities []float64) []int {
+	n := len(probabilities)
 	sortedTeams := make([][2]int, n)
-	for i, prob := range normalizedProbabilities {
+	for i, prob := range probabilities {
 		sortedTeams[i] = [2]int{i, int(math.Round(prob * 1000000))}
 	}
 	sort.SliceStable(sortedTeams, func(i, j int) bool {
@@ -188,8 +224,67 @@ func (p predictor) ChanceOfRanks(teams [][]Rating) ([]int, []float64, error) {
 		}
 		ranks[team[0]] = currentRank
 	}
+	return ranks
+}
+
+// MatchQuality returns how fair a match between the teams is as a number between 0 and 1, as in TrueSkill.
+// It is the likelihood of all teams performing equally, relative to the likelihood if every team were exactly equal in
+// skill, so it decreases both with differences in mu and with uncertainty in sigma.
+func (p predictor) MatchQuality(teams [][]Rating) (float64, error) {
+	if err := checkTeams(teams); err != nil {
+		return 0, err
+	}
+
+	teamRatings := calculateTeamRatings(teams, nil, p.balance, p.kappa)
+	n := len(teams) - 1
+
+	// The covariance of the performance differences between consecutive teams, with and without the uncertainty in
+	// skill, and the mean of the differences.
+	withSkill := make([][]float64, n)
+	withoutSkill := make([][]float64, n)
+	differences := make([]float64, n)
+	for k := 0; k < n; k++ {
+		withSkill[k] = make([]float64, n)
+		withoutSkill[k] = make([]float64, n)
+		differences[k] = teamRatings[k].Mu - teamRatings[k+1].Mu
+	}
+	for i, team := range teamRatings {
+		performance := float64(len(team.Team)) * p.beta * p.beta
+		for k := max(i-1, 0); k <= min(i, n-1); k++ {
+			for l := max(i-1, 0); l <= min(i, n-1); l++ {
+				sign := 1.0
+				if k != l {
+					sign = -1
+				}
+				withSkill[k][l] += sign * (performance + team.SigmaSquared)
+				withoutSkill[k][l] += sign * performance
+			}
+		}
+	}
+
+	withSkillFactor, ok := cholesky(withSkill)
+	if !ok {
+		return 0, nil
+	}
+	withoutSkillFactor, ok := cholesky(withoutSkill)
+	if !ok {
+		return 0, nil
+	}
+
+	// sqrt(det(withoutSkill) / det(withSkill)) from the diagonals of the Cholesky factors.
+	ratio := 1.0
+	for k := 0; k < n; k++ {
+		ratio *= withoutSkillFactor[k][k] / withSkillFactor[k][k]
+	}
+
+	// differences' * withSkill^-1 * differences as the squared norm of L^-1 * differences.
+	y := forwardSubstitution(withSkillFactor, differences)
+	exponent := 0.0
+	for _, v := range y {
+		exponent += v * v
+	}
 
-	return ranks, normalizedProbabilities, nil
+	return ratio * math.Exp(-exponent/2), nil
 }
 
 // checkTeams validates teams input for the predictor methods.
@@ -1,6 +1,7 @@
 package openskill
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,3 +54,187 @@ func TestCheckTeams(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestModelPredictor(t *testing.T) {
+	t.Parallel()
+
+	teams := [][]Rating{{{25, 3}}, {{10, 2.5}, {5, 2}}, {{17, 2}}}
+
+	t.Run("models use the predictor of their likelihood", func(t *testing.T) {
+		tests := []struct {
+			model     Model
+			predictor Predictor
+		}{
+			{DefaultPlackettLuceModel(), DefaultPlackettLucePredictor()},
+			{DefaultBradlyTerryFullModel(), DefaultBradlyTerryPredictor()},
+			{DefaultBradlyTerryPartialModel(), DefaultBradlyTerryPredictor()},
+			{DefaultThurstoneMostellerFullModel(), DefaultThurstoneMostellerPredictor()},
+			{DefaultThurstoneMostellerPartialModel(), newThurstoneMostellerPartialPredictor(25.0/6.0, 0.0001, 0.1, false)},
+		}
+
+		for _, test := range tests {
+			expected, err := test.predictor.ChanceOfWinning(teams)
+			assert.NoError(t, err)
+			actual, err := test.model.ChanceOfWinning(teams)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("custom model uses its own parameters", func(t *testing.T) {
+		m := NewThurstoneMostellerFullModel(25, 25.0/3.0, 10, 0.001, 25.0/300.0, 0.2, false, true)
+		p := NewThurstoneMostellerPredictor(10, 0.001, 0.2, true)
+
+		expected, err := p.ChanceOfDraw(teams)
+		assert.NoError(t, err)
+		actual, err := m.ChanceOfDraw(teams)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestBradlyTerryPredictor(t *testing.T) {
+	t.Parallel()
+
+	p := DefaultBradlyTerryPredictor()
+	beta := 25.0 / 6.0
+
+	probabilities, err := p.ChanceOfWinning([][]Rating{{{30, 3}}, {{25, 4}}})
+	assert.NoError(t, err)
+
+	c := math.Sqrt(2*beta*beta + 9 + 16)
+	expected := 1 / (1 + math.Exp(-5/c))
+	assert.InDeltaSlice(t, []float64{expected, 1 - expected}, probabilities, 1e-12)
+
+	ranks, probabilities, err := p.ChanceOfRanks([][]Rating{{{20, 3}}, {{30, 3}}, {{25, 3}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 1, 2}, ranks)
+	assert.InDelta(t, 1, probabilities[0]+probabilities[1]+probabilities[2], 1e-12)
+
+	_, err = p.ChanceOfWinning([][]Rating{{{25, 3}}})
+	assert.ErrorIs(t, err, ErrLessThanTwoTeams)
+}
+
+func TestThurstoneMostellerPredictor(t *testing.T) {
+	t.Parallel()
+
+	teams := [][]Rating{{{30, 3}}, {{25, 4}}, {{20, 2}, {15, 1}}}
+
+	t.Run("without draw margin matches default predictor", func(t *testing.T) {
+		expected, err := DefaultPredictor().ChanceOfWinning(teams)
+		assert.NoError(t, err)
+		actual, err := NewThurstoneMostellerPredictor(25.0/6.0, 0.0001, 0, false).ChanceOfWinning(teams)
+		assert.NoError(t, err)
+
+		assert.InDeltaSlice(t, expected, actual, 1e-12)
+	})
+
+	t.Run("draws within the margin", func(t *testing.T) {
+		p := DefaultThurstoneMostellerPredictor()
+
+		draw, err := p.ChanceOfDraw([][]Rating{{{25, 3}}, {{25, 3}}})
+		assert.NoError(t, err)
+		assert.InDelta(t, 2*phiMajor(0.1)-1, draw, 1e-12)
+
+		unequal, err := p.ChanceOfDraw([][]Rating{{{35, 3}}, {{25, 3}}})
+		assert.NoError(t, err)
+		assert.Less(t, unequal, draw)
+	})
+
+	t.Run("partial model scale", func(t *testing.T) {
+		m := DefaultThurstoneMostellerPartialModel()
+		beta := 25.0 / 6.0
+		c := 2 * math.Sqrt(2*beta*beta+9+16)
+		deltaMu := 5 / c
+		epsilon := 0.1 / c
+
+		draw, err := m.ChanceOfDraw([][]Rating{{{30, 3}}, {{25, 4}}})
+		assert.NoError(t, err)
+		assert.InDelta(t, phiMajor(epsilon-deltaMu)-phiMajor(-epsilon-deltaMu), draw, 1e-12)
+
+		probabilities, err := m.ChanceOfWinning([][]Rating{{{30, 3}}, {{25, 4}}})
+		assert.NoError(t, err)
+		win, loss := phiMajor(deltaMu-epsilon), phiMajor(-deltaMu-epsilon)
+		assert.InDelta(t, win/(win+loss), probabilities[0], 1e-12)
+
+		full, err := DefaultThurstoneMostellerFullModel().ChanceOfDraw([][]Rating{{{30, 3}}, {{25, 4}}})
+		assert.NoError(t, err)
+		assert.NotEqual(t, full, draw)
+	})
+
+	t.Run("ranks", func(t *testing.T) {
+		ranks, _, err := DefaultThurstoneMostellerPredictor().ChanceOfRanks(teams)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2, 3, 1}, ranks)
+
+		_, err = DefaultThurstoneMostellerPredictor().ChanceOfDraw([][]Rating{{{25, 3}}, {}})
+		assert.ErrorIs(t, err, ErrEmptyTeam)
+	})
+}
+
+func TestMatchQuality(t *testing.T) {
+	t.Parallel()
+
+	p := DefaultPredictor().(QualityPredictor)
+	beta := 25.0 / 6.0
+
+	t.Run("two new players", func(t *testing.T) {
+		quality, err := p.MatchQuality([][]Rating{{{25, 25.0 / 3.0}}, {{25, 25.0 / 3.0}}})
+
+		assert.NoError(t, err)
+		assert.InDelta(t, math.Sqrt(0.2), quality, 1e-12)
+	})
+
+	t.Run("two teams", func(t *testing.T) {
+		teams := [][]Rating{{{30, 4}, {20, 3}}, {{22, 5}, {25, 2}}}
+		variance := 4*beta*beta + 16 + 9 + 25 + 4
+		expected := math.Sqrt(4*beta*beta/variance) * math.Exp(-(50.0-47.0)*(50.0-47.0)/(2*variance))
+
+		quality, err := p.MatchQuality(teams)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, quality, 1e-12)
+	})
+
+	t.Run("multiple teams", func(t *testing.T) {
+		fair := [][]Rating{{{25, 3}}, {{25, 3}}, {{25, 3}}}
+		unfair := [][]Rating{{{35, 3}}, {{25, 3}}, {{15, 3}}}
+		uncertain := [][]Rating{{{25, 8}}, {{25, 8}}, {{25, 8}}}
+
+		fairQuality, err := p.MatchQuality(fair)
+		assert.NoError(t, err)
+		unfairQuality, err := p.MatchQuality(unfair)
+		assert.NoError(t, err)
+		uncertainQuality, err := p.MatchQuality(uncertain)
+		assert.NoError(t, err)
+
+		assert.LessOrEqual(t, fairQuality, 1.0)
+		assert.Less(t, unfairQuality, fairQuality)
+		assert.Less(t, uncertainQuality, fairQuality)
+
+		reordered, err := p.MatchQuality([][]Rating{unfair[2], unfair[0], unfair[1]})
+		assert.NoError(t, err)
+		assert.InDelta(t, unfairQuality, reordered, 1e-12)
+	})
+
+	t.Run("model matches predictor", func(t *testing.T) {
+		teams := [][]Rating{{{25, 3}}, {{10, 2.5}, {5, 2}}, {{17, 2}}}
+
+		expected, err := p.MatchQuality(teams)
+		assert.NoError(t, err)
+		actual, err := DefaultBradlyTerryFullModel().MatchQuality(teams)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("invalid teams", func(t *testing.T) {
+		_, err := p.MatchQuality([][]Rating{{{25, 3}}})
+		assert.ErrorIs(t, err, ErrLessThanTwoTeams)
+
+		_, err = p.MatchQuality([][]Rating{{{25, 3}}, {}})
+		assert.ErrorIs(t, err, ErrEmptyTeam)
+	})
+}
@@ -0,0 +1,92 @@
+package openskill
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// DistributionPredictor is a Predictor that can also estimate the distribution of finishing positions. All predictors
+// and models in this package implement it, including the Predictor returned by DefaultPredictor and NewPredictor.
+type DistributionPredictor interface {
+	Predictor
+	RankDistribution(teams [][]Rating, samples int, seed uint64) (RankDistribution, error)
+}
+
+// RankDistribution is the distribution of the finishing positions of the teams in a match.
+type RankDistribution struct {
+	// Probabilities[team][position] is the probability of the team finishing in the position, where 0 is first.
+	Probabilities [][]float64
+	// ExpectedPosition is the mean finishing position of each team, where 1 is first.
+	ExpectedPosition []float64
+	// PositionVariance is the variance of the finishing position of each team.
+	PositionVariance []float64
+}
+
+// RankDistribution estimates the probability of each team finishing in each position by sampling the performance of
+// every team from a normal distribution around its mu, with the uncertainty of its sigma and beta for each player.
+// The same seed always gives the same result.
+func (p predictor) RankDistribution(teams [][]Rating, samples int, seed uint64) (RankDistribution, error) {
+	if err := checkTeams(teams); err != nil {
+		return RankDistribution{}, err
+	}
+	if samples <= 0 {
+		return RankDistribution{}, ErrInvalidSampleCount
+	}
+
+	teamRatings := calculateTeamRatings(teams, nil, p.balance, p.kappa)
+	deviations := make([]float64, len(teams))
+	for i, t := range teamRatings {
+		deviations[i] = math.Sqrt(t.SigmaSquared + float64(len(t.Team))*p.beta*p.beta)
+	}
+
+	return sampleRankDistribution(len(teams), samples, seed, func(rng *rand.Rand, performances []float64) {
+		for i, t := range teamRatings {
+			performances[i] = t.Mu + deviations[i]*rng.NormFloat64()
+		}
+	}), nil
+}
+
+// sampleRankDistribution counts the finishing positions of n teams over samples draws of their performances,
+// where a higher performance finishes ahead.
+func sampleRankDistribution(n, samples int, seed uint64, draw func(rng *rand.Rand, performances []float64)) RankDistribution {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	performances := make([]float64, n)
+	order := make([]int, n)
+
+	counts := make([][]int, n)
+	for i := range counts {
+		counts[i] = make([]int, n)
+	}
+
+	for range samples {
+		draw(rng, performances)
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool {
+			return performances[order[i]] > performances[order[j]]
+		})
+		for position, team := range order {
+			counts[team][position]++
+		}
+	}
+
+	result := RankDistribution{
+		Probabilities:    make([][]float64, n),
+		ExpectedPosition: make([]float64, n),
+		PositionVariance: make([]float64, n),
+	}
+	for team := range counts {
+		result.Probabilities[team] = make([]float64, n)
+		squared := 0.0
+		for position, count := range counts[team] {
+			probability := float64(count) / float64(samples)
+			result.Probabilities[team][position] = probability
+			result.ExpectedPosition[team] += float64(position+1) * probability
+			squared += float64((position+1)*(position+1)) * probability
+		}
+		result.PositionVariance[team] = math.Max(squared-result.ExpectedPosition[team]*result.ExpectedPosition[team], 0)
+	}
+	return result
+}
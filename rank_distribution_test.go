@@ -0,0 +1,86 @@
+package openskill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankDistribution(t *testing.T) {
+	t.Parallel()
+
+	teams := [][]Rating{{{30, 3}}, {{25, 5}, {5, 2}}, {{20, 4}}, {{28, 8}}}
+	predictors := []DistributionPredictor{DefaultPredictor().(DistributionPredictor), DefaultPlackettLucePredictor(), DefaultThurstoneMostellerFullModel()}
+
+	for _, p := range predictors {
+		distribution, err := p.RankDistribution(teams, 20000, 42)
+		assert.NoError(t, err)
+
+		for team := range teams {
+			rowSum, columnSum := 0.0, 0.0
+			for position := range teams {
+				rowSum += distribution.Probabilities[team][position]
+				columnSum += distribution.Probabilities[position][team]
+			}
+			assert.InDelta(t, 1, rowSum, 1e-9)
+			assert.InDelta(t, 1, columnSum, 1e-9)
+			assert.GreaterOrEqual(t, distribution.ExpectedPosition[team], 1.0)
+			assert.LessOrEqual(t, distribution.ExpectedPosition[team], 4.0)
+			assert.GreaterOrEqual(t, distribution.PositionVariance[team], 0.0)
+		}
+		assert.Less(t, distribution.ExpectedPosition[0], distribution.ExpectedPosition[2])
+
+		again, err := p.RankDistribution(teams, 20000, 42)
+		assert.NoError(t, err)
+		assert.Equal(t, distribution, again)
+
+		other, err := p.RankDistribution(teams, 20000, 43)
+		assert.NoError(t, err)
+		assert.NotEqual(t, distribution, other)
+	}
+}
+
+func TestRankDistributionTwoTeams(t *testing.T) {
+	t.Parallel()
+
+	teams := [][]Rating{{{30, 3}}, {{25, 5}}}
+
+	t.Run("gaussian", func(t *testing.T) {
+		p := DefaultPredictor().(DistributionPredictor)
+		winning, err := p.ChanceOfWinning(teams)
+		assert.NoError(t, err)
+
+		distribution, err := p.RankDistribution(teams, 50000, 1)
+		assert.NoError(t, err)
+
+		assert.InDelta(t, winning[0], distribution.Probabilities[0][0], 0.01)
+		assert.InDelta(t, 2-winning[0], distribution.ExpectedPosition[0], 0.01)
+		assert.InDelta(t, winning[0]*(1-winning[0]), distribution.PositionVariance[0], 0.01)
+	})
+
+	t.Run("plackett-luce", func(t *testing.T) {
+		p := DefaultPlackettLucePredictor()
+		winning, err := p.ChanceOfWinning(teams)
+		assert.NoError(t, err)
+
+		distribution, err := p.RankDistribution(teams, 50000, 1)
+		assert.NoError(t, err)
+
+		assert.InDelta(t, winning[0], distribution.Probabilities[0][0], 0.01)
+	})
+}
+
+func TestRankDistributionErrors(t *testing.T) {
+	t.Parallel()
+
+	p := DefaultPredictor().(DistributionPredictor)
+
+	_, err := p.RankDistribution([][]Rating{{{25, 3}}}, 100, 1)
+	assert.ErrorIs(t, err, ErrLessThanTwoTeams)
+
+	_, err = p.RankDistribution([][]Rating{{{25, 3}}, {{25, 3}}}, 0, 1)
+	assert.ErrorIs(t, err, ErrInvalidSampleCount)
+
+	_, err = DefaultPlackettLucePredictor().RankDistribution([][]Rating{{{25, 3}}, {}}, 100, 1)
+	assert.ErrorIs(t, err, ErrEmptyTeam)
+}
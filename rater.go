@@ -1,10 +1,78 @@
 package openskill
 
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
 // All models implement the Rater interface.
 type Rater interface {
 	Rate(teams [][]Rating, ranks, scores []int, weights [][]float64) (updatedRatings [][]Rating, err error)
 }
 
+// Model bundles a Rater with a Predictor using the same beta, kappa and balance values,
+// can rate structured matches, can create new ratings from the model's configured prior
+// and can inflate the sigma of inactive players.
+type Model interface {
+	Rater
+	QualityPredictor
+	DistributionPredictor
+	RateMatch(match Match) (updatedRatings [][]Rating, err error)
+	NewRating(opts ...RatingOption) Rating
+	Decay(rating Rating, lastPlayed, now time.Time) Rating
+	// Ranking returns the ranks the model rates the outcome with, where scores within the model's tie tolerance tie.
+	Ranking(outcome Outcome) []int
+}
+
+// models holds the options constructor of every model by the name accepted by ModelByName.
+var models = map[string]func(opts ...Option) (Model, error){
+	"plackett-luce":               NewPlackettLuceModelWithOptions,
+	"bradley-terry-full":          NewBradlyTerryFullModelWithOptions,
+	"bradley-terry-partial":       NewBradlyTerryPartialModelWithOptions,
+	"thurstone-mosteller-full":    NewThurstoneMostellerFullModelWithOptions,
+	"thurstone-mosteller-partial": NewThurstoneMostellerPartialModelWithOptions,
+}
+
+// ModelNames returns the names accepted by ModelByName in alphabetical order.
+func ModelNames() []string {
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ModelByName returns the model with the given name, e.g. "plackett-luce", configured by the options as with its
+// New...ModelWithOptions constructor. An error wrapping ErrUnknownModel is returned if no model has the name.
+func ModelByName(name string, opts ...Option) (Model, error) {
+	newModel, ok := models[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownModel, name)
+	}
+
+	return newModel(opts...)
+}
+
+// RatingOption overrides a value of a rating created by Model.NewRating.
+type RatingOption func(*Rating)
+
+// WithRatingMu overrides the mean of a new rating.
+func WithRatingMu(mu float64) RatingOption {
+	return func(r *Rating) {
+		r.Mu = mu
+	}
+}
+
+// WithRatingSigma overrides the standard deviation of a new rating.
+func WithRatingSigma(sigma float64) RatingOption {
+	return func(r *Rating) {
+		r.Sigma = sigma
+	}
+}
+
 // Rating represents a player's skill level as a Gaussian distribution with a mean (Mu) and standard deviation (Sigma).
 type Rating struct {
 	Mu    float64
@@ -16,8 +84,17 @@ func (r Rating) Ordinal() float64 {
 	return r.Mu - 3*r.Sigma
 }
 
+// newRating returns a rating with the given prior after applying the options.
+func newRating(mu, sigma float64, opts []RatingOption) Rating {
+	r := Rating{Mu: mu, Sigma: sigma}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
 // checkRateParameters validates the input parameters for the Rate method.
-func checkRateParameters(teams [][]Rating, ranks, scores []int, weights [][]float64) error {
+func checkRateParameters(teams [][]Rating, ranks []int, scores []float64, weights [][]float64) error {
 	if len(teams) < 2 {
 		return ErrLessThanTwoTeams
 	}
@@ -49,6 +126,34 @@ func checkRateParameters(teams [][]Rating, ranks, scores []int, weights [][]floa
 			if len(teams[i]) != len(teamWeights) {
 				return ErrWeightsAndTeamsMismatch
 			}
+			for _, weight := range teamWeights {
+				if math.IsNaN(weight) || math.IsInf(weight, 0) {
+					return ErrInvalidWeight
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPartialPlay validates that partial play, if provided, has the same shape as teams and values in (0, 1].
+func checkPartialPlay(teams [][]Rating, partialPlay [][]float64) error {
+	if partialPlay == nil {
+		return nil
+	}
+
+	if len(teams) != len(partialPlay) {
+		return ErrPartialPlayAndTeamsMismatch
+	}
+	for i, teamPartialPlay := range partialPlay {
+		if len(teams[i]) != len(teamPartialPlay) {
+			return ErrPartialPlayAndTeamsMismatch
+		}
+		for _, share := range teamPartialPlay {
+			if !(share > 0 && share <= 1) {
+				return ErrInvalidPartialPlay
+			}
 		}
 	}
 
@@ -1,6 +1,7 @@
 package openskill
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -42,7 +43,7 @@ func TestCheckRateParameters(t *testing.T) {
 		t1 := []Rating{{1, 2}}
 		t2 := []Rating{{1, 2}}
 
-		err := checkRateParameters([][]Rating{t1, t2}, []int{1, 2}, []int{1, 2}, nil)
+		err := checkRateParameters([][]Rating{t1, t2}, []int{1, 2}, []float64{1, 2}, nil)
 
 		assert.ErrorIs(t, err, ErrRanksAndScores)
 	})
@@ -69,7 +70,7 @@ func TestCheckRateParameters(t *testing.T) {
 		t1 := []Rating{{1, 2}}
 		t2 := []Rating{{1, 2}}
 
-		err := checkRateParameters([][]Rating{t1, t2}, nil, []int{1}, nil)
+		err := checkRateParameters([][]Rating{t1, t2}, nil, []float64{1}, nil)
 
 		assert.ErrorIs(t, err, ErrScoresAndTeamsMismatch)
 	})
@@ -92,6 +93,17 @@ func TestCheckRateParameters(t *testing.T) {
 		assert.ErrorIs(t, err, ErrWeightsAndTeamsMismatch)
 	})
 
+	t.Run("non-finite weight", func(t *testing.T) {
+		t1 := []Rating{{1, 2}}
+		t2 := []Rating{{1, 2}}
+
+		err := checkRateParameters([][]Rating{t1, t2}, []int{1, 2}, nil, [][]float64{{1}, {math.NaN()}})
+		assert.ErrorIs(t, err, ErrInvalidWeight)
+
+		err = checkRateParameters([][]Rating{t1, t2}, []int{1, 2}, nil, [][]float64{{math.Inf(1)}, {1}})
+		assert.ErrorIs(t, err, ErrInvalidWeight)
+	})
+
 	t.Run("valid", func(t *testing.T) {
 		t1 := []Rating{{1, 2}}
 		t2 := []Rating{{1, 2}}
@@ -101,3 +113,114 @@ func TestCheckRateParameters(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestNewRating(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default prior", func(t *testing.T) {
+		models := defaultModels()
+
+		for _, m := range models {
+			assert.Equal(t, Rating{Mu: 25, Sigma: 25.0 / 3.0}, m.NewRating())
+		}
+	})
+
+	t.Run("custom prior", func(t *testing.T) {
+		m := NewPlackettLuceModel(1500, 350, 200, 0.0001, false, false)
+
+		assert.Equal(t, Rating{Mu: 1500, Sigma: 350}, m.NewRating())
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		m := DefaultThurstoneMostellerFullModel()
+
+		assert.Equal(t, Rating{Mu: 30, Sigma: 25.0 / 3.0}, m.NewRating(WithRatingMu(30)))
+		assert.Equal(t, Rating{Mu: 25, Sigma: 2}, m.NewRating(WithRatingSigma(2)))
+		assert.Equal(t, Rating{Mu: 30, Sigma: 2}, m.NewRating(WithRatingMu(30), WithRatingSigma(2)))
+	})
+}
+
+func TestModelByName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("every model", func(t *testing.T) {
+		names := ModelNames()
+		assert.Len(t, names, len(allModels))
+
+		for i, name := range names {
+			m, err := ModelByName(name, WithMu(10))
+			assert.NoError(t, err)
+			assert.Equal(t, Rating{Mu: 10, Sigma: 25.0 / 3.0}, m.NewRating())
+
+			if i > 0 {
+				assert.Less(t, names[i-1], name)
+			}
+		}
+	})
+
+	t.Run("constructor", func(t *testing.T) {
+		expected, err := NewThurstoneMostellerFullModelWithOptions(WithBeta(5))
+		assert.NoError(t, err)
+
+		actual, err := ModelByName("thurstone-mosteller-full", WithBeta(5))
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		m, err := ModelByName("elo")
+
+		assert.ErrorIs(t, err, ErrUnknownModel)
+		assert.Nil(t, m)
+	})
+
+	t.Run("invalid parameter", func(t *testing.T) {
+		_, err := ModelByName("plackett-luce", WithBeta(0))
+
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestCheckPartialPlay(t *testing.T) {
+	t.Parallel()
+
+	t1 := []Rating{{1, 2}}
+	t2 := []Rating{{1, 2}, {1, 2}}
+
+	t.Run("nil partial play", func(t *testing.T) {
+		err := checkPartialPlay([][]Rating{t1, t2}, nil)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("team missing partial play", func(t *testing.T) {
+		err := checkPartialPlay([][]Rating{t1, t2}, [][]float64{{1}})
+
+		assert.ErrorIs(t, err, ErrPartialPlayAndTeamsMismatch)
+	})
+
+	t.Run("player missing partial play", func(t *testing.T) {
+		err := checkPartialPlay([][]Rating{t1, t2}, [][]float64{{1}, {1}})
+
+		assert.ErrorIs(t, err, ErrPartialPlayAndTeamsMismatch)
+	})
+
+	t.Run("zero partial play", func(t *testing.T) {
+		err := checkPartialPlay([][]Rating{t1, t2}, [][]float64{{1}, {0, 1}})
+
+		assert.ErrorIs(t, err, ErrInvalidPartialPlay)
+	})
+
+	t.Run("partial play above one", func(t *testing.T) {
+		err := checkPartialPlay([][]Rating{t1, t2}, [][]float64{{1.5}, {1, 1}})
+
+		assert.ErrorIs(t, err, ErrInvalidPartialPlay)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		err := checkPartialPlay([][]Rating{t1, t2}, [][]float64{{1}, {0.25, 1}})
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,8 @@
+package server
+
+import "fmt"
+
+var (
+	ErrEmptyPlayerID = fmt.Errorf("player IDs must not be empty")
+	ErrNoPlayers     = fmt.Errorf("at least one player must be requested")
+)
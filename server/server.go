@@ -0,0 +1,292 @@
+// Package server serves ratings and predictions of a Model over HTTP with a JSON API, storing the ratings in a
+// RatingStore. Players are identified by strings.
+//
+// The endpoints are:
+//
+//	POST /matches      rates a match and returns the new ratings of its players
+//	POST /predictions  predicts the chance of each team winning, of a draw and the most likely ranks
+//	GET  /players/{id} returns the rating of a player
+//	GET  /players?id=a&id=b returns the ratings of the players that have one
+//
+// Invalid requests, such as matches with fewer than two teams, are answered with 400 Bad Request if the body is not
+// valid JSON and 422 Unprocessable Entity otherwise. Errors are returned as {"error": "..."}.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	openskill "github.com/Sebsh1/openskill.go"
+)
+
+// maxBodySize is the largest request body accepted, in bytes.
+const maxBodySize = 1 << 20
+
+// validationErrors are the errors caused by invalid matches, which are answered with 422 Unprocessable Entity.
+var validationErrors = []error{
+	openskill.ErrLessThanTwoTeams,
+	openskill.ErrEmptyTeam,
+	openskill.ErrNoRanksOrScores,
+	openskill.ErrRanksAndScores,
+	openskill.ErrRanksAndTeamsMismatch,
+	openskill.ErrScoresAndTeamsMismatch,
+	openskill.ErrWeightsAndTeamsMismatch,
+	openskill.ErrInvalidWeight,
+	openskill.ErrPartialPlayAndTeamsMismatch,
+	openskill.ErrInvalidPartialPlay,
+	openskill.ErrPartialPlayNotSupported,
+	openskill.ErrDuplicatePlayer,
+	ErrEmptyPlayerID,
+	ErrNoPlayers,
+}
+
+// Handler is an http.Handler serving the JSON API. It is safe for concurrent use.
+type Handler struct {
+	model openskill.Model
+	store openskill.RatingStore[string]
+	mux   *http.ServeMux
+	// rateMu serializes rating, since matches sharing players must not be rated concurrently against a store.
+	rateMu sync.Mutex
+}
+
+// NewHandler returns a Handler rating and predicting with the model and keeping the ratings in the store.
+func NewHandler(model openskill.Model, store openskill.RatingStore[string]) *Handler {
+	h := &Handler{model: model, store: store, mux: http.NewServeMux()}
+	h.mux.HandleFunc("POST /matches", h.rate)
+	h.mux.HandleFunc("POST /predictions", h.predict)
+	h.mux.HandleFunc("GET /players/{id}", h.player)
+	h.mux.HandleFunc("GET /players", h.players)
+	return h
+}
+
+// ServeHTTP serves a request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// MatchRequest is the body of POST /matches. Exactly one of Ranks and Scores must be set.
+type MatchRequest struct {
+	ID          string      `json:"id,omitempty"`
+	Time        time.Time   `json:"time"`
+	Teams       [][]string  `json:"teams"`
+	Ranks       []int       `json:"ranks,omitempty"`
+	Scores      []float64   `json:"scores,omitempty"`
+	Weights     [][]float64 `json:"weights,omitempty"`
+	PartialPlay [][]float64 `json:"partial_play,omitempty"`
+}
+
+// MatchResponse is the body of the response to POST /matches.
+type MatchResponse struct {
+	Ratings map[string]Rating `json:"ratings"`
+}
+
+// PredictionRequest is the body of POST /predictions. Players without a rating are predicted as new players.
+type PredictionRequest struct {
+	Teams [][]string `json:"teams"`
+}
+
+// PredictionResponse is the body of the response to POST /predictions.
+type PredictionResponse struct {
+	// Win is the chance of each team winning.
+	Win []float64 `json:"win"`
+	// Draw is the chance of the match ending in a draw.
+	Draw float64 `json:"draw"`
+	// Ranks is the most likely rank of each team, where a lower rank is better.
+	Ranks []int `json:"ranks"`
+}
+
+// PlayersResponse is the body of the response to GET /players.
+type PlayersResponse struct {
+	Ratings map[string]Rating `json:"ratings"`
+}
+
+// Rating is the rating of a player together with its ordinal.
+type Rating struct {
+	Mu      float64 `json:"mu"`
+	Sigma   float64 `json:"sigma"`
+	Ordinal float64 `json:"ordinal"`
+}
+
+// errorResponse is the body of responses to failed requests.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) rate(w http.ResponseWriter, r *http.Request) {
+	var req MatchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	match := openskill.PlayerMatch[string]{
+		ID:          req.ID,
+		Time:        req.Time,
+		Teams:       req.Teams,
+		Weights:     req.Weights,
+		PartialPlay: req.PartialPlay,
+	}
+	switch {
+	case req.Ranks != nil && req.Scores != nil:
+		writeError(w, openskill.ErrRanksAndScores)
+		return
+	case req.Ranks != nil:
+		match.Outcome = openskill.Ranks(req.Ranks...)
+	case req.Scores != nil:
+		match.Outcome = openskill.Scores(req.Scores...)
+	}
+	if err := checkPlayerIDs(req.Teams); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.rateMu.Lock()
+	updated, err := openskill.RateAndStore(h.model, h.store, match)
+	h.rateMu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, MatchResponse{Ratings: ratings(updated)})
+}
+
+func (h *Handler) predict(w http.ResponseWriter, r *http.Request) {
+	var req PredictionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := checkPlayerIDs(req.Teams); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	match := openskill.PlayerMatch[string]{Teams: req.Teams}
+	stored, err := h.store.GetMany(match.Players())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resolved, err := match.Resolve(stored, func() openskill.Rating {
+		return h.model.NewRating()
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ranks, win, err := h.model.ChanceOfRanks(resolved.Teams)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	draw, err := h.model.ChanceOfDraw(resolved.Teams)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PredictionResponse{Win: win, Draw: draw, Ranks: ranks})
+}
+
+func (h *Handler) player(w http.ResponseWriter, r *http.Request) {
+	rating, ok, err := h.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !ok {
+		writeError(w, openskill.ErrUnknownPlayer)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newRating(rating))
+}
+
+func (h *Handler) players(w http.ResponseWriter, r *http.Request) {
+	ids := r.URL.Query()["id"]
+	if len(ids) == 0 {
+		writeError(w, ErrNoPlayers)
+		return
+	}
+	if err := checkPlayerIDs([][]string{ids}); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	stored, err := h.store.GetMany(ids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PlayersResponse{Ratings: ratings(stored)})
+}
+
+// checkPlayerIDs returns ErrEmptyPlayerID if any player ID is empty.
+func checkPlayerIDs(teams [][]string) error {
+	for _, team := range teams {
+		for _, player := range team {
+			if player == "" {
+				return ErrEmptyPlayerID
+			}
+		}
+	}
+	return nil
+}
+
+// decode decodes the JSON request body into v, answering with 400 Bad Request and returning false if it is invalid.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body: " + err.Error()})
+		return false
+	}
+	if decoder.More() {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body: unexpected data after JSON value"})
+		return false
+	}
+	return true
+}
+
+// writeError answers with the status matching err. Unexpected errors, such as store failures, are logged and
+// answered with 500 Internal Server Error without exposing their message.
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, openskill.ErrUnknownPlayer) {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+		return
+	}
+	for _, validationErr := range validationErrors {
+		if errors.Is(err, validationErr) {
+			writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	log.Printf("openskill server: %v", err)
+	writeJSON(w, http.StatusInternalServerError, errorResponse{Error: http.StatusText(http.StatusInternalServerError)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("openskill server: writing response: %v", err)
+	}
+}
+
+func newRating(r openskill.Rating) Rating {
+	return Rating{Mu: r.Mu, Sigma: r.Sigma, Ordinal: r.Ordinal()}
+}
+
+func ratings(ratings map[string]openskill.Rating) map[string]Rating {
+	result := make(map[string]Rating, len(ratings))
+	for player, r := range ratings {
+		result[player] = newRating(r)
+	}
+	return result
+}
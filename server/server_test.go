@@ -0,0 +1,182 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore is a RatingStore whose every call fails.
+type failingStore struct{}
+
+func (failingStore) Get(string) (openskill.Rating, bool, error) {
+	return openskill.Rating{}, false, fmt.Errorf("store is down")
+}
+
+func (failingStore) GetMany([]string) (map[string]openskill.Rating, error) {
+	return nil, fmt.Errorf("store is down")
+}
+
+func (failingStore) Put(string, map[string]openskill.Rating) error {
+	return fmt.Errorf("store is down")
+}
+
+// do sends a request to the handler and decodes the JSON response into v.
+func do(t *testing.T, h http.Handler, method, target, body string, v any) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if v != nil {
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), v), rec.Body.String())
+	}
+	return rec.Code
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	model := openskill.DefaultThurstoneMostellerFullModel()
+	store := openskill.NewMemoryStore[string]()
+	h := NewHandler(model, store)
+
+	t.Run("rate", func(t *testing.T) {
+		var resp MatchResponse
+		code := do(t, h, http.MethodPost, "/matches", `{"id": "m1", "teams": [["alice", "bob"], ["carol"]], "ranks": [1, 2]}`, &resp)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, resp.Ratings, 3)
+		assert.Greater(t, resp.Ratings["alice"].Mu, resp.Ratings["carol"].Mu)
+
+		stored, ok, _ := store.Get("alice")
+		assert.True(t, ok)
+		assert.Equal(t, stored.Mu, resp.Ratings["alice"].Mu)
+		assert.Equal(t, stored.Ordinal(), resp.Ratings["alice"].Ordinal)
+
+		var second MatchResponse
+		code = do(t, h, http.MethodPost, "/matches", `{"teams": [["alice"], ["dave"]], "scores": [10, 3]}`, &second)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, second.Ratings, 2)
+	})
+
+	t.Run("predict", func(t *testing.T) {
+		var resp PredictionResponse
+		code := do(t, h, http.MethodPost, "/predictions", `{"teams": [["alice"], ["carol"], ["newcomer"]]}`, &resp)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, resp.Win, 3)
+		assert.Equal(t, 1, resp.Ranks[0])
+		assert.Greater(t, resp.Win[0], resp.Win[1])
+		assert.Greater(t, resp.Draw, 0.0)
+
+		_, ok, _ := store.Get("newcomer")
+		assert.False(t, ok, "predictions must not store new players")
+	})
+
+	t.Run("players", func(t *testing.T) {
+		var rating Rating
+		code := do(t, h, http.MethodGet, "/players/alice", "", &rating)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Greater(t, rating.Mu, 25.0)
+
+		var resp PlayersResponse
+		code = do(t, h, http.MethodGet, "/players?id=alice&id=carol&id=nobody", "", &resp)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, resp.Ratings, 2)
+
+		var e errorResponse
+		code = do(t, h, http.MethodGet, "/players/nobody", "", &e)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, openskill.ErrUnknownPlayer.Error(), e.Error)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		before := store.All()
+
+		tests := []struct {
+			name   string
+			method string
+			target string
+			body   string
+			status int
+			err    error
+		}{
+			{"one team", http.MethodPost, "/matches", `{"teams": [["alice"]], "ranks": [1]}`, http.StatusUnprocessableEntity, openskill.ErrLessThanTwoTeams},
+			{"empty team", http.MethodPost, "/matches", `{"teams": [["alice"], []], "ranks": [1, 2]}`, http.StatusUnprocessableEntity, openskill.ErrEmptyTeam},
+			{"no outcome", http.MethodPost, "/matches", `{"teams": [["alice"], ["bob"]]}`, http.StatusUnprocessableEntity, openskill.ErrNoRanksOrScores},
+			{"ranks and scores", http.MethodPost, "/matches", `{"teams": [["alice"], ["bob"]], "ranks": [1, 2], "scores": [1, 2]}`, http.StatusUnprocessableEntity, openskill.ErrRanksAndScores},
+			{"ranks mismatch", http.MethodPost, "/matches", `{"teams": [["alice"], ["bob"]], "ranks": [1]}`, http.StatusUnprocessableEntity, openskill.ErrRanksAndTeamsMismatch},
+			{"weights mismatch", http.MethodPost, "/matches", `{"teams": [["alice"], ["bob"]], "ranks": [1, 2], "weights": [[1]]}`, http.StatusUnprocessableEntity, openskill.ErrWeightsAndTeamsMismatch},
+			{"duplicate player", http.MethodPost, "/matches", `{"teams": [["alice"], ["alice"]], "ranks": [1, 2]}`, http.StatusUnprocessableEntity, openskill.ErrDuplicatePlayer},
+			{"empty player", http.MethodPost, "/matches", `{"teams": [[""], ["bob"]], "ranks": [1, 2]}`, http.StatusUnprocessableEntity, ErrEmptyPlayerID},
+			{"predict one team", http.MethodPost, "/predictions", `{"teams": [["alice"]]}`, http.StatusUnprocessableEntity, openskill.ErrLessThanTwoTeams},
+			{"no players", http.MethodGet, "/players", "", http.StatusUnprocessableEntity, ErrNoPlayers},
+			{"malformed body", http.MethodPost, "/matches", `{"teams": `, http.StatusBadRequest, nil},
+			{"unknown field", http.MethodPost, "/matches", `{"teams": [["alice"], ["bob"]], "rank": [1, 2]}`, http.StatusBadRequest, nil},
+			{"trailing data", http.MethodPost, "/predictions", `{"teams": [["alice"], ["bob"]]} {}`, http.StatusBadRequest, nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var e errorResponse
+				code := do(t, h, tt.method, tt.target, tt.body, &e)
+
+				assert.Equal(t, tt.status, code)
+				if tt.err != nil {
+					assert.True(t, strings.HasPrefix(e.Error, tt.err.Error()), e.Error)
+				} else {
+					assert.Contains(t, e.Error, "invalid request body")
+				}
+			})
+		}
+
+		assert.Equal(t, before, store.All(), "invalid matches must not be stored")
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		code := do(t, h, http.MethodGet, "/matches", "", nil)
+		assert.Equal(t, http.StatusMethodNotAllowed, code)
+	})
+}
+
+func TestHandlerInvalidWeights(t *testing.T) {
+	t.Parallel()
+
+	model, err := openskill.NewThurstoneMostellerFullModelWithOptions(openskill.WithWeightStrategy(openskill.RawWeights()))
+	assert.NoError(t, err)
+	store := openskill.NewMemoryStore[string]()
+	h := NewHandler(model, store)
+
+	var e errorResponse
+	code := do(t, h, http.MethodPost, "/matches", `{"teams": [["alice"], ["bob"]], "ranks": [1, 2], "weights": [[1], [0]]}`, &e)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, openskill.ErrInvalidWeight.Error(), e.Error)
+	assert.Empty(t, store.All())
+}
+
+func TestHandlerStoreErrors(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(openskill.DefaultPlackettLuceModel(), failingStore{})
+
+	var e errorResponse
+	code := do(t, h, http.MethodPost, "/matches", `{"teams": [["alice"], ["bob"]], "ranks": [1, 2]}`, &e)
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.NotContains(t, e.Error, "store is down")
+
+	code = do(t, h, http.MethodPost, "/predictions", `{"teams": [["alice"], ["bob"]]}`, &e)
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	code = do(t, h, http.MethodGet, "/players/alice", "", &e)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
@@ -0,0 +1,169 @@
+package openskill
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sqlRating is the JSON representation of a Rating in a database column.
+type sqlRating struct {
+	Mu    float64 `json:"mu"`
+	Sigma float64 `json:"sigma"`
+}
+
+// Value implements driver.Valuer so a Rating can be stored in a single text or JSON column as {"mu":...,"sigma":...}.
+func (r Rating) Value() (driver.Value, error) {
+	b, err := json.Marshal(sqlRating{Mu: r.Mu, Sigma: r.Sigma})
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner for ratings stored by Value. Use sql.Null[Rating] for nullable columns.
+func (r *Rating) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	case nil:
+		return fmt.Errorf("cannot scan NULL into Rating")
+	default:
+		return fmt.Errorf("cannot scan %T into Rating", src)
+	}
+
+	var s sqlRating
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("scanning Rating: %w", err)
+	}
+	r.Mu, r.Sigma = s.Mu, s.Sigma
+	return nil
+}
+
+// Placeholder returns the bind parameter for the n-th argument of a query, starting at 1.
+type Placeholder func(n int) string
+
+// QuestionPlaceholder is the placeholder style of MySQL and SQLite: ?.
+func QuestionPlaceholder(int) string {
+	return "?"
+}
+
+// DollarPlaceholder is the placeholder style of PostgreSQL: $1, $2, ...
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// SQLStore is a RatingStore backed by a database/sql database. The ratings are kept in a table with the columns
+// player, mu and sigma, where player is the primary key, e.g. for PostgreSQL:
+//
+//	CREATE TABLE ratings (player TEXT PRIMARY KEY, mu DOUBLE PRECISION NOT NULL, sigma DOUBLE PRECISION NOT NULL)
+//
+// The keys must be types the driver can bind and scan.
+type SQLStore[K comparable] struct {
+	db          *sql.DB
+	table       string
+	placeholder Placeholder
+}
+
+// NewSQLStore returns a SQLStore using the given table, which is inserted into the queries as is.
+// If placeholder is nil, QuestionPlaceholder is used.
+func NewSQLStore[K comparable](db *sql.DB, table string, placeholder Placeholder) *SQLStore[K] {
+	if placeholder == nil {
+		placeholder = QuestionPlaceholder
+	}
+	return &SQLStore[K]{db: db, table: table, placeholder: placeholder}
+}
+
+// Get returns the rating of the player, or false if the player has no rating.
+func (s *SQLStore[K]) Get(player K) (Rating, bool, error) {
+	query := fmt.Sprintf("SELECT mu, sigma FROM %s WHERE player = %s", s.table, s.placeholder(1))
+
+	var r Rating
+	err := s.db.QueryRow(query, player).Scan(&r.Mu, &r.Sigma)
+	if err == sql.ErrNoRows {
+		return Rating{}, false, nil
+	}
+	if err != nil {
+		return Rating{}, false, err
+	}
+	return r, true, nil
+}
+
+// GetMany returns the ratings of the players that have one.
+func (s *SQLStore[K]) GetMany(players []K) (map[K]Rating, error) {
+	result := make(map[K]Rating, len(players))
+	if len(players) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(players))
+	args := make([]any, len(players))
+	for i, player := range players {
+		placeholders[i] = s.placeholder(i + 1)
+		args[i] = player
+	}
+	query := fmt.Sprintf("SELECT player, mu, sigma FROM %s WHERE player IN (%s)", s.table, strings.Join(placeholders, ", "))
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var player K
+		var r Rating
+		if err := rows.Scan(&player, &r.Mu, &r.Sigma); err != nil {
+			return nil, err
+		}
+		result[player] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Put stores the updated ratings of the players in a match in a single transaction. Existing players are updated and
+// new players inserted, which relies on RowsAffected counting matched rows; for MySQL set clientFoundRows=true.
+func (s *SQLStore[K]) Put(matchID string, ratings map[K]Rating) (err error) {
+	update := fmt.Sprintf("UPDATE %s SET mu = %s, sigma = %s WHERE player = %s",
+		s.table, s.placeholder(1), s.placeholder(2), s.placeholder(3))
+	insert := fmt.Sprintf("INSERT INTO %s (player, mu, sigma) VALUES (%s, %s, %s)",
+		s.table, s.placeholder(1), s.placeholder(2), s.placeholder(3))
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for player, r := range ratings {
+		result, err := tx.Exec(update, r.Mu, r.Sigma, player)
+		if err != nil {
+			return fmt.Errorf("updating %v in match %s: %w", player, matchID, err)
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if updated > 0 {
+			continue
+		}
+		if _, err := tx.Exec(insert, player, r.Mu, r.Sigma); err != nil {
+			return fmt.Errorf("inserting %v in match %s: %w", player, matchID, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,276 @@
+package openskill
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver is an in-process database/sql driver that understands only the queries issued by SQLStore.
+// Every data source name is a separate database.
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+// fakeDB is a table of ratings keyed by player.
+type fakeDB struct {
+	mu      sync.Mutex
+	ratings map[any]Rating
+	// failOn makes any statement binding this player fail.
+	failOn any
+}
+
+var fakeSQL = &fakeDriver{dbs: make(map[string]*fakeDB)}
+
+func init() {
+	sql.Register("openskill-fake", fakeSQL)
+}
+
+// openFakeDB returns a database/sql handle to a new, empty fake database.
+func openFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	fakeSQL.mu.Lock()
+	fdb := &fakeDB{ratings: make(map[any]Rating)}
+	fakeSQL.dbs[t.Name()] = fdb
+	fakeSQL.mu.Unlock()
+
+	db, err := sql.Open("openskill-fake", t.Name())
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, fdb
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	db, ok := d.dbs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown database %s", name)
+	}
+	return &fakeConn{db: db}, nil
+}
+
+// fakeConn is a connection to a fakeDB. Inside a transaction, writes go to a copy of the table until commit.
+type fakeConn struct {
+	db *fakeDB
+	tx map[any]Rating
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: dollarPlaceholders.ReplaceAllString(query, "?")}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.tx = make(map[any]Rating, len(c.db.ratings))
+	for player, r := range c.db.ratings {
+		c.tx[player] = r
+	}
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.db.ratings, c.tx = c.tx, nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.tx = nil
+	return nil
+}
+
+// table returns the table the connection currently reads and writes. The caller must hold db.mu.
+func (c *fakeConn) table() map[any]Rating {
+	if c.tx != nil {
+		return c.tx
+	}
+	return c.db.ratings
+}
+
+var dollarPlaceholders = regexp.MustCompile(`\$\d+`)
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return strings.Count(s.query, "?") }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.db.mu.Lock()
+	defer s.conn.db.mu.Unlock()
+
+	table := s.conn.table()
+	switch {
+	case strings.HasPrefix(s.query, "UPDATE ratings SET mu = ?, sigma = ? WHERE player = ?"):
+		if args[2] == s.conn.db.failOn {
+			return nil, errors.New("update failed")
+		}
+		if _, ok := table[args[2]]; !ok {
+			return driver.RowsAffected(0), nil
+		}
+		table[args[2]] = Rating{args[0].(float64), args[1].(float64)}
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "INSERT INTO ratings (player, mu, sigma) VALUES (?, ?, ?)"):
+		if args[0] == s.conn.db.failOn {
+			return nil, errors.New("insert failed")
+		}
+		table[args[0]] = Rating{args[1].(float64), args[2].(float64)}
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unsupported statement %q", s.query)
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.db.mu.Lock()
+	defer s.conn.db.mu.Unlock()
+
+	table := s.conn.table()
+	switch {
+	case strings.HasPrefix(s.query, "SELECT mu, sigma FROM ratings WHERE player = ?"):
+		rows := &fakeRows{columns: []string{"mu", "sigma"}}
+		if r, ok := table[args[0]]; ok {
+			rows.values = append(rows.values, []driver.Value{r.Mu, r.Sigma})
+		}
+		return rows, nil
+	case strings.HasPrefix(s.query, "SELECT player, mu, sigma FROM ratings WHERE player IN ("):
+		rows := &fakeRows{columns: []string{"player", "mu", "sigma"}}
+		for _, player := range args {
+			if r, ok := table[player]; ok {
+				rows.values = append(rows.values, []driver.Value{player, r.Mu, r.Sigma})
+			}
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("unsupported query %q", s.query)
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestRatingValueScan(t *testing.T) {
+	t.Parallel()
+
+	r := Rating{Mu: 27.5, Sigma: 6.25}
+
+	v, err := r.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"mu":27.5,"sigma":6.25}`, v)
+
+	var fromString Rating
+	assert.NoError(t, fromString.Scan(v))
+	assert.Equal(t, r, fromString)
+
+	var fromBytes Rating
+	assert.NoError(t, fromBytes.Scan([]byte(`{"mu":27.5,"sigma":6.25}`)))
+	assert.Equal(t, r, fromBytes)
+
+	var invalid Rating
+	assert.Error(t, invalid.Scan(nil))
+	assert.Error(t, invalid.Scan(42))
+	assert.Error(t, invalid.Scan("not json"))
+}
+
+func TestSQLStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get and put", func(t *testing.T) {
+		db, _ := openFakeDB(t)
+		s := NewSQLStore[string](db, "ratings", nil)
+
+		_, ok, err := s.Get("alice")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		assert.NoError(t, s.Put("1", map[string]Rating{"alice": {30, 5}, "bob": {20, 4}}))
+		assert.NoError(t, s.Put("2", map[string]Rating{"alice": {31, 4}}))
+
+		r, ok, err := s.Get("alice")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, Rating{31, 4}, r)
+
+		many, err := s.GetMany([]string{"alice", "bob", "carol"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]Rating{"alice": {31, 4}, "bob": {20, 4}}, many)
+
+		none, err := s.GetMany(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
+	t.Run("dollar placeholders and integer keys", func(t *testing.T) {
+		db, _ := openFakeDB(t)
+		s := NewSQLStore[int64](db, "ratings", DollarPlaceholder)
+
+		assert.NoError(t, s.Put("1", map[int64]Rating{7: {30, 5}, 8: {20, 4}}))
+
+		many, err := s.GetMany([]int64{7, 8})
+		assert.NoError(t, err)
+		assert.Equal(t, map[int64]Rating{7: {30, 5}, 8: {20, 4}}, many)
+	})
+
+	t.Run("failed put is rolled back", func(t *testing.T) {
+		db, fdb := openFakeDB(t)
+		s := NewSQLStore[string](db, "ratings", nil)
+		assert.NoError(t, s.Put("1", map[string]Rating{"alice": {30, 5}}))
+
+		fdb.failOn = "bob"
+		err := s.Put("2", map[string]Rating{"alice": {31, 4}, "bob": {20, 4}})
+
+		assert.Error(t, err)
+		assert.Equal(t, map[any]Rating{"alice": {30, 5}}, fdb.ratings)
+	})
+
+	t.Run("rate and store", func(t *testing.T) {
+		db, _ := openFakeDB(t)
+		s := NewSQLStore[string](db, "ratings", nil)
+		m := DefaultBradlyTerryFullModel()
+		match := PlayerMatch[string]{ID: "1", Teams: [][]string{{"alice"}, {"bob"}}, Outcome: Ranks(1, 2)}
+
+		updated, err := RateAndStore[string](m, s, match)
+		assert.NoError(t, err)
+
+		stored, err := s.GetMany([]string{"alice", "bob"})
+		assert.NoError(t, err)
+		assert.Equal(t, updated, stored)
+	})
+}
+
+func TestPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "?", QuestionPlaceholder(3))
+	assert.Equal(t, "$3", DollarPlaceholder(3))
+}
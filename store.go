@@ -0,0 +1,89 @@
+package openskill
+
+import "sync"
+
+// RatingStore stores the current rating of players identified by keys of type K.
+type RatingStore[K comparable] interface {
+	// Get returns the rating of the player, or false if the player has no rating.
+	Get(player K) (Rating, bool, error)
+	// GetMany returns the ratings of the players that have one.
+	GetMany(players []K) (map[K]Rating, error)
+	// Put stores the updated ratings of the players in a match atomically.
+	Put(matchID string, ratings map[K]Rating) error
+}
+
+// MemoryStore is a RatingStore that keeps the ratings in memory. It is safe for concurrent use.
+type MemoryStore[K comparable] struct {
+	mu      sync.RWMutex
+	ratings map[K]Rating
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore[K comparable]() *MemoryStore[K] {
+	return &MemoryStore[K]{ratings: make(map[K]Rating)}
+}
+
+// Get returns the rating of the player, or false if the player has no rating.
+func (m *MemoryStore[K]) Get(player K) (Rating, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	r, ok := m.ratings[player]
+	return r, ok, nil
+}
+
+// GetMany returns the ratings of the players that have one.
+func (m *MemoryStore[K]) GetMany(players []K) (map[K]Rating, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[K]Rating, len(players))
+	for _, player := range players {
+		if r, ok := m.ratings[player]; ok {
+			result[player] = r
+		}
+	}
+	return result, nil
+}
+
+// Put stores the updated ratings of the players in a match.
+func (m *MemoryStore[K]) Put(matchID string, ratings map[K]Rating) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for player, r := range ratings {
+		m.ratings[player] = r
+	}
+	return nil
+}
+
+// All returns a copy of every stored rating.
+func (m *MemoryStore[K]) All() map[K]Rating {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[K]Rating, len(m.ratings))
+	for player, r := range m.ratings {
+		result[player] = r
+	}
+	return result
+}
+
+// RateAndStore loads the ratings of the players in the match from the store, rates the match like RatePlayers
+// and stores the updated ratings. Matches sharing players must not be rated concurrently against the same store.
+func RateAndStore[K comparable](r Rater, store RatingStore[K], match PlayerMatch[K]) (map[K]Rating, error) {
+	ratings, err := store.GetMany(match.Players())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := RatePlayers(r, match, ratings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Put(match.ID, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
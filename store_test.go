@@ -0,0 +1,95 @@
+package openskill
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore is a RatingStore where reading or writing fails.
+type failingStore struct {
+	*MemoryStore[string]
+	failGet bool
+	failPut bool
+}
+
+func (f failingStore) GetMany(players []string) (map[string]Rating, error) {
+	if f.failGet {
+		return nil, errors.New("get failed")
+	}
+	return f.MemoryStore.GetMany(players)
+}
+
+func (f failingStore) Put(matchID string, ratings map[string]Rating) error {
+	if f.failPut {
+		return errors.New("put failed")
+	}
+	return f.MemoryStore.Put(matchID, ratings)
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	s := NewMemoryStore[string]()
+
+	_, ok, err := s.Get("alice")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Put("1", map[string]Rating{"alice": {30, 5}, "bob": {20, 4}}))
+	assert.NoError(t, s.Put("2", map[string]Rating{"alice": {31, 4}}))
+
+	r, ok, err := s.Get("alice")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Rating{31, 4}, r)
+
+	many, err := s.GetMany([]string{"alice", "bob", "carol"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Rating{"alice": {31, 4}, "bob": {20, 4}}, many)
+
+	assert.Equal(t, map[string]Rating{"alice": {31, 4}, "bob": {20, 4}}, s.All())
+}
+
+func TestRateAndStore(t *testing.T) {
+	t.Parallel()
+
+	m := DefaultThurstoneMostellerFullModel()
+	match := PlayerMatch[string]{
+		ID:      "match-1",
+		Teams:   [][]string{{"alice"}, {"bob"}},
+		Outcome: Ranks(1, 2),
+	}
+
+	t.Run("stores updated ratings", func(t *testing.T) {
+		s := NewMemoryStore[string]()
+		assert.NoError(t, s.Put("0", map[string]Rating{"alice": {30, 5}}))
+
+		expected, err := RatePlayers(m, match, map[string]Rating{"alice": {30, 5}})
+		assert.NoError(t, err)
+
+		updated, err := RateAndStore[string](m, s, match)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, updated)
+		assert.Equal(t, expected, s.All())
+	})
+
+	t.Run("invalid match is not stored", func(t *testing.T) {
+		s := NewMemoryStore[string]()
+
+		_, err := RateAndStore[string](m, s, PlayerMatch[string]{Teams: [][]string{{"alice"}, {"bob"}}})
+
+		assert.ErrorIs(t, err, ErrNoRanksOrScores)
+		assert.Empty(t, s.All())
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		_, err := RateAndStore[string](m, failingStore{MemoryStore: NewMemoryStore[string](), failGet: true}, match)
+		assert.Error(t, err)
+
+		_, err = RateAndStore[string](m, failingStore{MemoryStore: NewMemoryStore[string](), failPut: true}, match)
+		assert.Error(t, err)
+	})
+}
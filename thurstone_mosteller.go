@@ -3,59 +3,113 @@ package openskill
 import (
 	"math"
 	"sort"
+	"time"
 )
 
 type ThurstoneMostellerFullModel struct {
+	ThurstoneMostellerPredictor
 	mu         float64
 	sigma      float64
-	beta       float64
-	kappa      float64
 	tau        float64
 	epsilon    float64
+	tolerance  tieTolerance
+	weighting  WeightStrategy
+	decay      DecayCurve
 	limitSigma bool
-	balance    bool
 }
 
-func DefaultThurstoneMostellerFullModel() Rater {
-	return ThurstoneMostellerFullModel{
-		mu:         25.0,
-		sigma:      25.0 / 3.0,
-		beta:       25.0 / 6.0,
-		kappa:      0.0001,
-		tau:        25.0 / 300.0,
-		epsilon:    0.1,
-		limitSigma: false,
-		balance:    false,
+// DefaultThurstoneMostellerFullModel returns a new ThurstoneMostellerFullModel with sensible default parameter values.
+func DefaultThurstoneMostellerFullModel() Model {
+	return newThurstoneMostellerFullModel(defaultOptions())
+}
+
+// NewThurstoneMostellerFullModel returns a new ThurstoneMostellerFullModel with custom parameter values.
+// The parameters are not validated, use NewThurstoneMostellerFullModelWithOptions to reject invalid values.
+func NewThurstoneMostellerFullModel(mu, sigma, beta, kappa, tau, epsilon float64, limitSigma, balance bool) Model {
+	return newThurstoneMostellerFullModel(applyOptions(
+		WithMu(mu),
+		WithSigma(sigma),
+		WithBeta(beta),
+		WithKappa(kappa),
+		WithTau(tau),
+		WithEpsilon(epsilon),
+		WithLimitSigma(limitSigma),
+		WithBalance(balance),
+	))
+}
+
+// NewThurstoneMostellerFullModelWithOptions returns a new ThurstoneMostellerFullModel configured by the options on top of the default parameter values.
+// An error wrapping ErrInvalidParameter is returned if any of the resulting parameters are invalid.
+func NewThurstoneMostellerFullModelWithOptions(opts ...Option) (Model, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
 	}
+
+	return newThurstoneMostellerFullModel(o), nil
 }
 
-func NewThurstoneMostellerFullModel(mu, sigma, beta, kappa, tau, epsilon float64, limitSigma, balance bool) Rater {
+func newThurstoneMostellerFullModel(o options) ThurstoneMostellerFullModel {
 	return ThurstoneMostellerFullModel{
-		mu:         mu,
-		sigma:      sigma,
-		beta:       beta,
-		kappa:      kappa,
-		tau:        tau,
-		epsilon:    epsilon,
-		limitSigma: limitSigma,
-		balance:    balance,
+		ThurstoneMostellerPredictor: NewThurstoneMostellerPredictor(o.beta, o.kappa, o.epsilon, o.balance),
+		mu:                          o.mu,
+		sigma:                       o.sigma,
+		tau:                         o.tau,
+		epsilon:                     o.epsilon,
+		tolerance:                   o.tolerance,
+		weighting:                   o.weighting,
+		decay:                       o.decay,
+		limitSigma:                  o.limitSigma,
 	}
 }
 
+// NewRating returns a new rating from the model's mu and sigma unless overridden.
+func (t ThurstoneMostellerFullModel) NewRating(opts ...RatingOption) Rating {
+	return newRating(t.mu, t.sigma, opts)
+}
+
+// Decay returns the rating with its sigma inflated by the model's decay curve for the time between lastPlayed and now,
+// capped at the model's prior sigma. The rating is returned unchanged if the model has no decay curve.
+func (t ThurstoneMostellerFullModel) Decay(rating Rating, lastPlayed, now time.Time) Rating {
+	return InflateSigma(rating, lastPlayed, now, t.decay, t.sigma)
+}
+
+// Ranking returns the ranks the model rates the outcome with, where scores within the model's tie tolerance tie.
+func (t ThurstoneMostellerFullModel) Ranking(outcome Outcome) []int {
+	return outcome.ranking(t.tolerance)
+}
+
+// Rate updates the ratings of the teams based on either their ranks or scores, optionally weighted per player.
 func (t ThurstoneMostellerFullModel) Rate(teams [][]Rating, ranks, scores []int, weights [][]float64) ([][]Rating, error) {
+	return t.rate(teams, ranks, intsToFloats(scores), weights, nil)
+}
+
+// RateMatch updates the ratings of the players in the match based on its outcome.
+// If the model has a decay curve, the sigma of each player is first inflated for the time since they last played.
+func (t ThurstoneMostellerFullModel) RateMatch(match Match) ([][]Rating, error) {
+	teams, err := decayTeams(match, t.decay, t.sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.rate(teams, match.Outcome.Ranks(), match.Outcome.Scores(), match.Weights, match.PartialPlay)
+}
+
+func (t ThurstoneMostellerFullModel) rate(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) ([][]Rating, error) {
 	if err := checkRateParameters(teams, ranks, scores, weights); err != nil {
 		return nil, err
 	}
+	if err := checkPartialPlay(teams, partialPlay); err != nil {
+		return nil, err
+	}
 
 	if ranks == nil && scores != nil {
-		ranks = make([]int, len(scores))
-		for i, score := range scores {
-			ranks[i] = -score
-		}
+		ranks = scoresToRanks(scores, t.tolerance)
 	}
 
-	for i := range weights {
-		weights[i] = normalize(weights[i], 1, 2)
+	weights, err := normalizeWeights(weights, t.weighting)
+	if err != nil {
+		return nil, err
 	}
 
 	teamsCopy := make([][]Rating, len(teams))
@@ -72,11 +126,17 @@ func (t ThurstoneMostellerFullModel) Rate(teams [][]Rating, ranks, scores []int,
 		}
 	}
 
-	tenet := make([]int, len(ranks))
-	for i := range ranks {
-		tenet[i] = i
-	}
+	var tenet []int
+	sortedPartialPlay := partialPlay
+	sortedTeams := teamsCopy
 	if ranks != nil {
+		sortedTeams, tenet = unwind(ranks, teamsCopy)
+		if weights != nil {
+			weights, _ = unwind(ranks, weights)
+		}
+		if partialPlay != nil {
+			sortedPartialPlay, _ = unwind(ranks, partialPlay)
+		}
 		sort.Ints(ranks)
 	}
 
@@ -84,7 +144,7 @@ func (t ThurstoneMostellerFullModel) Rate(teams [][]Rating, ranks, scores []int,
 	var result [][]Rating
 
 	if ranks != nil {
-		result = t.compute(teamsCopy, ranks, weights)
+		result = t.compute(sortedTeams, ranks, weights, sortedPartialPlay)
 		unwoundResult, _ := unwind(tenet, result)
 		for index, item := range unwoundResult {
 			team := make([]Rating, len(item))
@@ -92,7 +152,7 @@ func (t ThurstoneMostellerFullModel) Rate(teams [][]Rating, ranks, scores []int,
 			processedResult[index] = team
 		}
 	} else {
-		result = t.compute(teamsCopy, nil, weights)
+		result = t.compute(sortedTeams, nil, weights, sortedPartialPlay)
 		for index, item := range result {
 			team := make([]Rating, len(item))
 			copy(team, item)
@@ -103,28 +163,28 @@ func (t ThurstoneMostellerFullModel) Rate(teams [][]Rating, ranks, scores []int,
 
 	finalResult := processedResult
 
+	if partialPlay != nil {
+		finalResult = applyPartialPlay(teams, finalResult, partialPlay)
+	}
+
 	if t.limitSigma {
-		finalResult = make([][]Rating, len(processedResult))
-		for teamIndex, team := range processedResult {
-			finalTeam := make([]Rating, len(team))
+		for teamIndex, team := range finalResult {
 			for playerIndex, player := range team {
-				player.Sigma = math.Min(player.Sigma, teamsCopy[teamIndex][playerIndex].Sigma)
-				finalTeam[playerIndex] = player
+				finalResult[teamIndex][playerIndex].Sigma = math.Min(player.Sigma, teamsCopy[teamIndex][playerIndex].Sigma)
 			}
-			finalResult[teamIndex] = finalTeam
 		}
 	}
 	return finalResult, nil
 }
 
-func (t ThurstoneMostellerFullModel) compute(teams [][]Rating, ranks []int, weights [][]float64) [][]Rating {
+func (t ThurstoneMostellerFullModel) compute(teams [][]Rating, ranks []int, weights, partialPlay [][]float64) [][]Rating {
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
 		originalTeams[i] = make([]Rating, len(teams[i]))
 		copy(originalTeams[i], teams[i])
 	}
 
-	teamRatings := calculateTeamRatings(teams, ranks, t.balance, t.kappa)
+	teamRatings := calculatePartialTeamRatings(teams, ranks, partialPlay, t.balance, t.kappa)
 
 	result := make([][]Rating, len(teamRatings))
 	for i, teamIRating := range teamRatings {
@@ -178,56 +238,109 @@ func (t ThurstoneMostellerFullModel) compute(teams [][]Rating, ranks []int, weig
 }
 
 type ThurstoneMostellerPartialModel struct {
+	ThurstoneMostellerPredictor
 	mu         float64
 	sigma      float64
-	beta       float64
-	kappa      float64
 	tau        float64
 	epsilon    float64
+	tolerance  tieTolerance
+	weighting  WeightStrategy
+	decay      DecayCurve
 	limitSigma bool
-	balance    bool
 }
 
-func DefaultThurstoneMostellerPartialModel() Rater {
-	return ThurstoneMostellerPartialModel{
-		mu:         25.0,
-		sigma:      25.0 / 3.0,
-		beta:       25.0 / 6.0,
-		kappa:      0.0001,
-		tau:        25.0 / 300.0,
-		epsilon:    0.1,
-		limitSigma: false,
-		balance:    false,
+// DefaultThurstoneMostellerPartialModel returns a new ThurstoneMostellerPartialModel with sensible default parameter values.
+func DefaultThurstoneMostellerPartialModel() Model {
+	return newThurstoneMostellerPartialModel(defaultOptions())
+}
+
+// NewThurstoneMostellerPartialModel returns a new ThurstoneMostellerPartialModel with custom parameter values.
+// The parameters are not validated, use NewThurstoneMostellerPartialModelWithOptions to reject invalid values.
+func NewThurstoneMostellerPartialModel(mu, sigma, beta, kappa, epsilon, tau float64, limitSigma, balance bool) Model {
+	return newThurstoneMostellerPartialModel(applyOptions(
+		WithMu(mu),
+		WithSigma(sigma),
+		WithBeta(beta),
+		WithKappa(kappa),
+		WithTau(tau),
+		WithEpsilon(epsilon),
+		WithLimitSigma(limitSigma),
+		WithBalance(balance),
+	))
+}
+
+// NewThurstoneMostellerPartialModelWithOptions returns a new ThurstoneMostellerPartialModel configured by the options on top of the default parameter values.
+// An error wrapping ErrInvalidParameter is returned if any of the resulting parameters are invalid.
+func NewThurstoneMostellerPartialModelWithOptions(opts ...Option) (Model, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
 	}
+
+	return newThurstoneMostellerPartialModel(o), nil
 }
 
-func NewThurstoneMostellerPartialModel(mu, sigma, beta, kappa, epsilon, tau float64, limitSigma, balance bool) Rater {
+func newThurstoneMostellerPartialModel(o options) ThurstoneMostellerPartialModel {
 	return ThurstoneMostellerPartialModel{
-		mu:         mu,
-		sigma:      sigma,
-		beta:       beta,
-		kappa:      kappa,
-		tau:        tau,
-		epsilon:    epsilon,
-		limitSigma: limitSigma,
-		balance:    balance,
+		ThurstoneMostellerPredictor: newThurstoneMostellerPartialPredictor(o.beta, o.kappa, o.epsilon, o.balance),
+		mu:                          o.mu,
+		sigma:                       o.sigma,
+		tau:                         o.tau,
+		epsilon:                     o.epsilon,
+		tolerance:                   o.tolerance,
+		weighting:                   o.weighting,
+		decay:                       o.decay,
+		limitSigma:                  o.limitSigma,
 	}
 }
 
+// NewRating returns a new rating from the model's mu and sigma unless overridden.
+func (t ThurstoneMostellerPartialModel) NewRating(opts ...RatingOption) Rating {
+	return newRating(t.mu, t.sigma, opts)
+}
+
+// Decay returns the rating with its sigma inflated by the model's decay curve for the time between lastPlayed and now,
+// capped at the model's prior sigma. The rating is returned unchanged if the model has no decay curve.
+func (t ThurstoneMostellerPartialModel) Decay(rating Rating, lastPlayed, now time.Time) Rating {
+	return InflateSigma(rating, lastPlayed, now, t.decay, t.sigma)
+}
+
+// Ranking returns the ranks the model rates the outcome with, where scores within the model's tie tolerance tie.
+func (t ThurstoneMostellerPartialModel) Ranking(outcome Outcome) []int {
+	return outcome.ranking(t.tolerance)
+}
+
+// Rate updates the ratings of the teams based on either their ranks or scores, optionally weighted per player.
 func (t ThurstoneMostellerPartialModel) Rate(teams [][]Rating, ranks, scores []int, weights [][]float64) ([][]Rating, error) {
+	return t.rate(teams, ranks, intsToFloats(scores), weights, nil)
+}
+
+// RateMatch updates the ratings of the players in the match based on its outcome.
+// If the model has a decay curve, the sigma of each player is first inflated for the time since they last played.
+func (t ThurstoneMostellerPartialModel) RateMatch(match Match) ([][]Rating, error) {
+	teams, err := decayTeams(match, t.decay, t.sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.rate(teams, match.Outcome.Ranks(), match.Outcome.Scores(), match.Weights, match.PartialPlay)
+}
+
+func (t ThurstoneMostellerPartialModel) rate(teams [][]Rating, ranks []int, scores []float64, weights, partialPlay [][]float64) ([][]Rating, error) {
 	if err := checkRateParameters(teams, ranks, scores, weights); err != nil {
 		return nil, err
 	}
+	if err := checkPartialPlay(teams, partialPlay); err != nil {
+		return nil, err
+	}
 
 	if ranks == nil && scores != nil {
-		ranks = make([]int, len(scores))
-		for i, score := range scores {
-			ranks[i] = -score
-		}
+		ranks = scoresToRanks(scores, t.tolerance)
 	}
 
-	for i := range weights {
-		weights[i] = normalize(weights[i], 1, 2)
+	weights, err := normalizeWeights(weights, t.weighting)
+	if err != nil {
+		return nil, err
 	}
 
 	teamsCopy := make([][]Rating, len(teams))
@@ -244,11 +357,17 @@ func (t ThurstoneMostellerPartialModel) Rate(teams [][]Rating, ranks, scores []i
 		}
 	}
 
-	tenet := make([]int, len(ranks))
-	for i := range ranks {
-		tenet[i] = i
-	}
+	var tenet []int
+	sortedPartialPlay := partialPlay
+	sortedTeams := teamsCopy
 	if ranks != nil {
+		sortedTeams, tenet = unwind(ranks, teamsCopy)
+		if weights != nil {
+			weights, _ = unwind(ranks, weights)
+		}
+		if partialPlay != nil {
+			sortedPartialPlay, _ = unwind(ranks, partialPlay)
+		}
 		sort.Ints(ranks)
 	}
 
@@ -256,7 +375,7 @@ func (t ThurstoneMostellerPartialModel) Rate(teams [][]Rating, ranks, scores []i
 	var result [][]Rating
 
 	if ranks != nil {
-		result = t.compute(teamsCopy, ranks, weights)
+		result = t.compute(sortedTeams, ranks, weights, sortedPartialPlay)
 		unwoundResult, _ := unwind(tenet, result)
 		for index, item := range unwoundResult {
 			team := make([]Rating, len(item))
@@ -264,7 +383,7 @@ func (t ThurstoneMostellerPartialModel) Rate(teams [][]Rating, ranks, scores []i
 			processedResult[index] = team
 		}
 	} else {
-		result = t.compute(teamsCopy, nil, weights)
+		result = t.compute(sortedTeams, nil, weights, sortedPartialPlay)
 		for index, item := range result {
 			team := make([]Rating, len(item))
 			copy(team, item)
@@ -275,27 +394,27 @@ func (t ThurstoneMostellerPartialModel) Rate(teams [][]Rating, ranks, scores []i
 
 	finalResult := processedResult
 
+	if partialPlay != nil {
+		finalResult = applyPartialPlay(teams, finalResult, partialPlay)
+	}
+
 	if t.limitSigma {
-		finalResult = make([][]Rating, len(processedResult))
-		for teamIndex, team := range processedResult {
-			finalTeam := make([]Rating, len(team))
+		for teamIndex, team := range finalResult {
 			for playerIndex, player := range team {
-				player.Sigma = math.Min(player.Sigma, teamsCopy[teamIndex][playerIndex].Sigma)
-				finalTeam[playerIndex] = player
+				finalResult[teamIndex][playerIndex].Sigma = math.Min(player.Sigma, teamsCopy[teamIndex][playerIndex].Sigma)
 			}
-			finalResult[teamIndex] = finalTeam
 		}
 	}
 	return finalResult, nil
 }
 
-func (t ThurstoneMostellerPartialModel) compute(teams [][]Rating, ranks []int, weights [][]float64) [][]Rating {
+func (t ThurstoneMostellerPartialModel) compute(teams [][]Rating, ranks []int, weights, partialPlay [][]float64) [][]Rating {
 	originalTeams := make([][]Rating, len(teams))
 	for i := range teams {
 		originalTeams[i] = make([]Rating, len(teams[i]))
 		copy(originalTeams[i], teams[i])
 	}
-	teamRatings := calculateTeamRatings(teams, ranks, t.balance, t.kappa)
+	teamRatings := calculatePartialTeamRatings(teams, ranks, partialPlay, t.balance, t.kappa)
 	adjacentTeams := ladderPairs(teamRatings)
 
 	result := make([][]Rating, len(teamRatings))
@@ -0,0 +1,98 @@
+package openskill
+
+import "math"
+
+// ThurstoneMostellerPredictor predicts matches with the Gaussian likelihood that the Thurstone-Mosteller models rate
+// with, including the draw margin epsilon: a team beats another with probability phi((mu_a - mu_b) / c - epsilon) and
+// draws with it when their difference is within epsilon.
+// The predictor of a ThurstoneMostellerPartialModel uses the scale of that model instead, where c is twice as large
+// and the draw margin is epsilon / c.
+// It falls back to the default predictor for match quality and rank distributions.
+type ThurstoneMostellerPredictor struct {
+	predictor
+	epsilon float64
+	partial bool
+}
+
+// DefaultThurstoneMostellerPredictor returns a new ThurstoneMostellerPredictor with sensible default parameter values.
+func DefaultThurstoneMostellerPredictor() ThurstoneMostellerPredictor {
+	return NewThurstoneMostellerPredictor(25.0/6.0, 0.0001, 0.1, false)
+}
+
+// NewThurstoneMostellerPredictor returns a new ThurstoneMostellerPredictor with custom parameter values.
+func NewThurstoneMostellerPredictor(beta, kappa, epsilon float64, balance bool) ThurstoneMostellerPredictor {
+	return ThurstoneMostellerPredictor{
+		predictor: predictor{
+			beta:    beta,
+			kappa:   kappa,
+			balance: balance,
+		},
+		epsilon: epsilon,
+	}
+}
+
+// newThurstoneMostellerPartialPredictor returns a ThurstoneMostellerPredictor that uses the scale of the
+// ThurstoneMostellerPartialModel.
+func newThurstoneMostellerPartialPredictor(beta, kappa, epsilon float64, balance bool) ThurstoneMostellerPredictor {
+	p := NewThurstoneMostellerPredictor(beta, kappa, epsilon, balance)
+	p.partial = true
+	return p
+}
+
+// normalize returns the difference in mu of the teams and the draw margin, both relative to the uncertainty of the
+// difference in their performance as the model of the predictor rates them.
+func (t ThurstoneMostellerPredictor) normalize(a, b teamRating) (deltaMu, epsilon float64) {
+	c := math.Sqrt(2*t.beta*t.beta + a.SigmaSquared + b.SigmaSquared)
+	if t.partial {
+		c *= 2
+		return (a.Mu - b.Mu) / c, t.epsilon / c
+	}
+	return (a.Mu - b.Mu) / c, t.epsilon
+}
+
+// ChanceOfWinning returns the probability of each team winning a match that is not drawn as a number between 0 and 1.
+// With more than two teams it is the average chance of beating each other team, normalized to sum to 1.
+func (t ThurstoneMostellerPredictor) ChanceOfWinning(teams [][]Rating) ([]float64, error) {
+	if err := checkTeams(teams); err != nil {
+		return nil, err
+	}
+
+	teamRatings := calculateTeamRatings(teams, nil, t.balance, t.kappa)
+	return pairwiseChanceOfWinning(teamRatings, t.beta, func(a, b teamRating, _ float64) float64 {
+		deltaMu, epsilon := t.normalize(a, b)
+		win := phiMajor(deltaMu - epsilon)
+		loss := phiMajor(-deltaMu - epsilon)
+		return win / (win + loss)
+	}), nil
+}
+
+// ChanceOfDraw returns the probability of a match ending in a draw as a number between 0 and 1, averaged over every
+// pair of teams.
+func (t ThurstoneMostellerPredictor) ChanceOfDraw(teams [][]Rating) (float64, error) {
+	if err := checkTeams(teams); err != nil {
+		return 0, err
+	}
+
+	teamRatings := calculateTeamRatings(teams, nil, t.balance, t.kappa)
+
+	sum := 0.0
+	pairs := 0
+	for i := 0; i < len(teamRatings); i++ {
+		for j := i + 1; j < len(teamRatings); j++ {
+			deltaMu, epsilon := t.normalize(teamRatings[i], teamRatings[j])
+			sum += phiMajor(epsilon-deltaMu) - phiMajor(-epsilon-deltaMu)
+			pairs++
+		}
+	}
+	return sum / float64(pairs), nil
+}
+
+// ChanceOfRanks returns the most likely ranking of the teams and the probability of each team winning.
+func (t ThurstoneMostellerPredictor) ChanceOfRanks(teams [][]Rating) ([]int, []float64, error) {
+	probabilities, err := t.ChanceOfWinning(teams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ranksFromProbabilities(probabilities), probabilities, nil
+}
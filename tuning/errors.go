@@ -0,0 +1,13 @@
+package tuning
+
+import "fmt"
+
+var (
+	ErrNoParameters  = fmt.Errorf("at least one parameter must be tuned")
+	ErrInvalidRange  = fmt.Errorf("parameter range is invalid")
+	ErrInvalidBudget = fmt.Errorf("number of steps, trials or evaluations must be positive")
+	ErrNoValidModel  = fmt.Errorf("no parameter values produced a valid model")
+
+	// errBudgetExhausted stops a search that has used all of its evaluations.
+	errBudgetExhausted = fmt.Errorf("evaluation budget exhausted")
+)
@@ -0,0 +1,343 @@
+// Package tuning searches for the model parameters that predict a set of historical matches best.
+package tuning
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/Sebsh1/openskill.go/backtest"
+)
+
+// Family builds a model of a family from options, e.g. openskill.NewThurstoneMostellerFullModelWithOptions.
+type Family func(opts ...openskill.Option) (openskill.Model, error)
+
+// Parameter is a model parameter to tune within [Min, Max].
+type Parameter struct {
+	Name   string
+	Option func(float64) openskill.Option
+	Min    float64
+	Max    float64
+	// Log searches the range on a logarithmic scale, which suits parameters spanning orders of magnitude.
+	// Min must be positive.
+	Log bool
+}
+
+// Beta tunes beta within [min, max].
+func Beta(min, max float64) Parameter {
+	return Parameter{Name: "beta", Option: openskill.WithBeta, Min: min, Max: max}
+}
+
+// Sigma tunes the sigma of new players within [min, max].
+func Sigma(min, max float64) Parameter {
+	return Parameter{Name: "sigma", Option: openskill.WithSigma, Min: min, Max: max}
+}
+
+// Tau tunes tau within [min, max].
+func Tau(min, max float64) Parameter {
+	return Parameter{Name: "tau", Option: openskill.WithTau, Min: min, Max: max}
+}
+
+// Kappa tunes kappa within [min, max] on a logarithmic scale.
+func Kappa(min, max float64) Parameter {
+	return Parameter{Name: "kappa", Option: openskill.WithKappa, Min: min, Max: max, Log: true}
+}
+
+// Epsilon tunes epsilon within [min, max].
+func Epsilon(min, max float64) Parameter {
+	return Parameter{Name: "epsilon", Option: openskill.WithEpsilon, Min: min, Max: max}
+}
+
+// Problem is a search for the parameters of a model family that minimize the log loss of a backtest over matches.
+// The log loss of the test set is minimized if Backtest.TestFrom is set, and of the train set otherwise.
+type Problem[K comparable] struct {
+	Family     Family
+	Parameters []Parameter
+	// Options are applied before the tuned parameters, e.g. to fix parameters that are not tuned.
+	Options  []openskill.Option
+	Matches  []openskill.PlayerMatch[K]
+	Backtest backtest.Config
+}
+
+// Result is the best model found by a search.
+type Result struct {
+	Model openskill.Model
+	// Values holds the value of each tuned parameter by name.
+	Values  map[string]float64
+	LogLoss float64
+	// Evaluations is the number of backtests run.
+	Evaluations int
+}
+
+// search keeps track of the best point of a search. Points are in the unit cube, with one coordinate per parameter.
+type search[K comparable] struct {
+	problem Problem[K]
+	best    Result
+}
+
+// Grid tries every combination of steps evenly spaced values of each parameter, including both ends of the ranges.
+// With a single step the middle of each range is tried.
+func Grid[K comparable](problem Problem[K], steps int) (Result, error) {
+	s, err := newSearch(problem)
+	if err != nil {
+		return Result{}, err
+	}
+	if steps <= 0 {
+		return Result{}, ErrInvalidBudget
+	}
+
+	point := make([]float64, len(problem.Parameters))
+	index := make([]int, len(problem.Parameters))
+	for {
+		for i, step := range index {
+			point[i] = 0.5
+			if steps > 1 {
+				point[i] = float64(step) / float64(steps-1)
+			}
+		}
+		if _, err := s.evaluate(point); err != nil {
+			return Result{}, err
+		}
+
+		i := 0
+		for ; i < len(index); i++ {
+			index[i]++
+			if index[i] < steps {
+				break
+			}
+			index[i] = 0
+		}
+		if i == len(index) {
+			return s.result()
+		}
+	}
+}
+
+// Random tries the given number of uniformly random parameter values, drawn from a random number generator seeded
+// with seed.
+func Random[K comparable](problem Problem[K], trials int, seed uint64) (Result, error) {
+	s, err := newSearch(problem)
+	if err != nil {
+		return Result{}, err
+	}
+	if trials <= 0 {
+		return Result{}, ErrInvalidBudget
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed))
+	point := make([]float64, len(problem.Parameters))
+	for range trials {
+		for i := range point {
+			point[i] = rng.Float64()
+		}
+		if _, err := s.evaluate(point); err != nil {
+			return Result{}, err
+		}
+	}
+	return s.result()
+}
+
+// NelderMead minimizes the log loss with the derivative-free Nelder-Mead simplex method, starting from the middle of
+// the ranges and stopping once maxEvaluations backtests have run or the simplex has converged. It never runs more than
+// maxEvaluations backtests, even if that cuts the initial simplex or an iteration short. Values outside the ranges are
+// clamped to them.
+func NelderMead[K comparable](problem Problem[K], maxEvaluations int) (Result, error) {
+	s, err := newSearch(problem)
+	if err != nil {
+		return Result{}, err
+	}
+	if maxEvaluations <= 0 {
+		return Result{}, ErrInvalidBudget
+	}
+
+	const (
+		reflection  = 1.0
+		expansion   = 2.0
+		contraction = 0.5
+		shrink      = 0.5
+		tolerance   = 1e-9
+	)
+
+	n := len(problem.Parameters)
+	type vertex struct {
+		point []float64
+		value float64
+	}
+	// evaluate returns errBudgetExhausted instead of running more than maxEvaluations backtests.
+	evaluate := func(point []float64) (vertex, error) {
+		if s.best.Evaluations >= maxEvaluations {
+			return vertex{}, errBudgetExhausted
+		}
+		value, err := s.evaluate(point)
+		return vertex{point: point, value: value}, err
+	}
+
+	simplex := make([]vertex, n+1)
+	for i := range simplex {
+		point := make([]float64, n)
+		for j := range point {
+			point[j] = 0.5
+		}
+		if i > 0 {
+			point[i-1] = 0.75
+		}
+		if simplex[i], err = evaluate(point); err != nil {
+			return s.stop(err)
+		}
+	}
+
+	// along returns the point centroid + factor * (centroid - worst).
+	along := func(centroid, worst []float64, factor float64) []float64 {
+		point := make([]float64, n)
+		for j := range point {
+			point[j] = centroid[j] + factor*(centroid[j]-worst[j])
+		}
+		return point
+	}
+
+	for {
+		sort.SliceStable(simplex, func(i, j int) bool {
+			return simplex[i].value < simplex[j].value
+		})
+		best, worst := simplex[0], simplex[n]
+		if math.Abs(worst.value-best.value) < tolerance {
+			break
+		}
+
+		centroid := make([]float64, n)
+		for _, v := range simplex[:n] {
+			for j := range centroid {
+				centroid[j] += v.point[j] / float64(n)
+			}
+		}
+
+		reflected, err := evaluate(along(centroid, worst.point, reflection))
+		if err != nil {
+			return s.stop(err)
+		}
+
+		switch {
+		case reflected.value < best.value:
+			expanded, err := evaluate(along(centroid, worst.point, expansion))
+			if err != nil {
+				return s.stop(err)
+			}
+			if expanded.value < reflected.value {
+				simplex[n] = expanded
+			} else {
+				simplex[n] = reflected
+			}
+		case reflected.value < simplex[n-1].value:
+			simplex[n] = reflected
+		default:
+			contracted, err := evaluate(along(centroid, worst.point, -contraction))
+			if err != nil {
+				return s.stop(err)
+			}
+			if contracted.value < worst.value {
+				simplex[n] = contracted
+				continue
+			}
+			for i := 1; i <= n; i++ {
+				point := make([]float64, n)
+				for j := range point {
+					point[j] = best.point[j] + shrink*(simplex[i].point[j]-best.point[j])
+				}
+				if simplex[i], err = evaluate(point); err != nil {
+					return s.stop(err)
+				}
+			}
+		}
+	}
+
+	return s.result()
+}
+
+func newSearch[K comparable](problem Problem[K]) (*search[K], error) {
+	if len(problem.Parameters) == 0 {
+		return nil, ErrNoParameters
+	}
+	for _, p := range problem.Parameters {
+		if p.Option == nil || !(p.Min <= p.Max) || (p.Log && p.Min <= 0) {
+			return nil, ErrInvalidRange
+		}
+	}
+	return &search[K]{problem: problem, best: Result{LogLoss: math.Inf(1)}}, nil
+}
+
+// values maps a point in the unit cube, clamped to it, to the values of the parameters.
+func (s *search[K]) values(point []float64) []float64 {
+	values := make([]float64, len(point))
+	for i, u := range point {
+		u = math.Min(math.Max(u, 0), 1)
+		p := s.problem.Parameters[i]
+		switch {
+		case u == 0:
+			values[i] = p.Min
+		case u == 1:
+			values[i] = p.Max
+		case p.Log:
+			values[i] = math.Exp(math.Log(p.Min) + u*(math.Log(p.Max)-math.Log(p.Min)))
+		default:
+			values[i] = p.Min + u*(p.Max-p.Min)
+		}
+	}
+	return values
+}
+
+// evaluate backtests the model at the point and returns its log loss, which is infinite if the family rejects the
+// parameter values. Errors from the backtest itself, such as invalid matches, are returned.
+func (s *search[K]) evaluate(point []float64) (float64, error) {
+	values := s.values(point)
+	opts := append([]openskill.Option(nil), s.problem.Options...)
+	for i, value := range values {
+		opts = append(opts, s.problem.Parameters[i].Option(value))
+	}
+
+	s.best.Evaluations++
+	model, err := s.problem.Family(opts...)
+	if err != nil {
+		return math.Inf(1), nil
+	}
+
+	result, err := backtest.Run(model, nil, s.problem.Matches, s.problem.Backtest)
+	if err != nil {
+		return 0, err
+	}
+
+	report := result.Train
+	if !s.problem.Backtest.TestFrom.IsZero() {
+		report = result.Test
+	}
+	logLoss := report.LogLoss
+	if report.Matches == 0 || math.IsNaN(logLoss) {
+		logLoss = math.Inf(1)
+	}
+
+	if logLoss < s.best.LogLoss {
+		s.best.Model = model
+		s.best.LogLoss = logLoss
+		s.best.Values = make(map[string]float64, len(values))
+		for i, value := range values {
+			s.best.Values[s.problem.Parameters[i].Name] = value
+		}
+	}
+	return logLoss, nil
+}
+
+// stop ends a search interrupted by err, returning the best result so far if the evaluation budget ran out.
+func (s *search[K]) stop(err error) (Result, error) {
+	if errors.Is(err, errBudgetExhausted) {
+		return s.result()
+	}
+	return Result{}, err
+}
+
+func (s *search[K]) result() (Result, error) {
+	if s.best.Model == nil {
+		return Result{}, ErrNoValidModel
+	}
+	return s.best, nil
+}
@@ -0,0 +1,118 @@
+package tuning
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	openskill "github.com/Sebsh1/openskill.go"
+	"github.com/Sebsh1/openskill.go/backtest"
+	"github.com/Sebsh1/openskill.go/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	problem := Problem[int]{
+		Family:     openskill.NewThurstoneMostellerFullModelWithOptions,
+		Parameters: []Parameter{Beta(1, 20), Tau(0, 1)},
+		Matches:    testutil.SyntheticMatches(300, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Backtest:   backtest.Config{WarmUpMatches: 50},
+	}
+
+	// logLoss backtests the model of a result again.
+	logLoss := func(result Result) float64 {
+		replay, err := backtest.Run(result.Model, nil, problem.Matches, problem.Backtest)
+		assert.NoError(t, err)
+		return replay.Train.LogLoss
+	}
+
+	t.Run("grid", func(t *testing.T) {
+		result, err := Grid(problem, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9, result.Evaluations)
+		assert.Contains(t, []float64{1, 10.5, 20}, result.Values["beta"])
+		assert.Contains(t, []float64{0, 0.5, 1}, result.Values["tau"])
+		assert.InDelta(t, logLoss(result), result.LogLoss, 1e-12)
+	})
+
+	t.Run("random", func(t *testing.T) {
+		result, err := Random(problem, 5, 1)
+		assert.NoError(t, err)
+		again, err := Random(problem, 5, 1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 5, result.Evaluations)
+		assert.Equal(t, result.Values, again.Values)
+		assert.GreaterOrEqual(t, result.Values["beta"], 1.0)
+		assert.LessOrEqual(t, result.Values["beta"], 20.0)
+		assert.InDelta(t, logLoss(result), result.LogLoss, 1e-12)
+	})
+
+	t.Run("nelder-mead", func(t *testing.T) {
+		center, err := Grid(problem, 1)
+		assert.NoError(t, err)
+
+		result, err := NelderMead(problem, 40)
+
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, result.LogLoss, center.LogLoss)
+		assert.LessOrEqual(t, result.Evaluations, 40)
+		assert.InDelta(t, logLoss(result), result.LogLoss, 1e-12)
+	})
+
+	t.Run("nelder-mead stays within budget", func(t *testing.T) {
+		for _, budget := range []int{1, 2, 3, 4, 5, 7, 10} {
+			result, err := NelderMead(problem, budget)
+
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, result.Evaluations, budget)
+			assert.InDelta(t, logLoss(result), result.LogLoss, 1e-12)
+		}
+	})
+
+	t.Run("log scale", func(t *testing.T) {
+		p := problem
+		p.Parameters = []Parameter{Kappa(0.0001, 0.01)}
+
+		result, err := Grid(p, 3)
+
+		assert.NoError(t, err)
+		kappa := result.Values["kappa"]
+		assert.True(t, kappa == 0.0001 || math.Abs(kappa-0.001) < 1e-15 || kappa == 0.01, kappa)
+	})
+
+	t.Run("invalid models", func(t *testing.T) {
+		p := problem
+		p.Parameters = []Parameter{Epsilon(-1, -0.5)}
+
+		_, err := Grid(p, 2)
+
+		assert.ErrorIs(t, err, ErrNoValidModel)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		p := problem
+		p.Parameters = nil
+		_, err := Grid(p, 2)
+		assert.ErrorIs(t, err, ErrNoParameters)
+
+		p.Parameters = []Parameter{Beta(2, 1)}
+		_, err = Random(p, 2, 1)
+		assert.ErrorIs(t, err, ErrInvalidRange)
+
+		p.Parameters = []Parameter{Kappa(0, 1)}
+		_, err = NelderMead(p, 2)
+		assert.ErrorIs(t, err, ErrInvalidRange)
+
+		_, err = Grid(problem, 0)
+		assert.ErrorIs(t, err, ErrInvalidBudget)
+
+		p = problem
+		p.Matches = []openskill.PlayerMatch[int]{{Teams: [][]int{{1}, {2}}}}
+		_, err = NelderMead(p, 10)
+		assert.ErrorIs(t, err, openskill.ErrNoRanksOrScores)
+	})
+}
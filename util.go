@@ -270,6 +270,77 @@ func sumQ(teamRatings []teamRating, c float64) []float64 {
 	return sumQ
 }
 
+// intsToFloats converts integer scores to floating-point scores, keeping nil as nil.
+func intsToFloats(values []int) []float64 {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]float64, len(values))
+	for i, value := range values {
+		result[i] = float64(value)
+	}
+	return result
+}
+
+// tieTolerance describes how close two scores must be to be treated as a draw.
+type tieTolerance struct {
+	absolute float64
+	relative float64
+}
+
+// ties reports whether the scores are within the absolute tolerance, or the relative tolerance of the larger score, of each other.
+func (t tieTolerance) ties(a, b float64) bool {
+	allowed := math.Max(t.absolute, t.relative*math.Max(math.Abs(a), math.Abs(b)))
+	return math.Abs(a-b) <= allowed
+}
+
+// scoresToRanks converts scores, where higher is better, to ranks starting at 1 where tied scores share a rank.
+// Scores are compared to the next higher score, so a chain of scores each within the tolerance of the next share a rank.
+func scoresToRanks(scores []float64, tolerance tieTolerance) []int {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	ranks := make([]int, len(scores))
+	for position, index := range order {
+		if position > 0 && tolerance.ties(scores[order[position-1]], scores[index]) {
+			ranks[index] = ranks[order[position-1]]
+		} else {
+			ranks[index] = position + 1
+		}
+	}
+	return ranks
+}
+
+// marginFactor scales the size of a mu update by the score gap between two teams.
+// A gap equal to the margin increases the update by ln(2) ≈ 69%, larger gaps grow logarithmically.
+func marginFactor(gap, margin float64) float64 {
+	if margin <= 0 {
+		return 1
+	}
+	return 1 + math.Log1p(math.Abs(gap)/margin)
+}
+
+// meanMarginFactor averages the margin factor of team i against every other team.
+func meanMarginFactor(scores []float64, i int, margin float64) float64 {
+	if len(scores) < 2 {
+		return 1
+	}
+
+	sum := 0.0
+	for j, score := range scores {
+		if j != i {
+			sum += marginFactor(scores[i]-score, margin)
+		}
+	}
+	return sum / float64(len(scores)-1)
+}
+
 // calculateRankings finds the order of the teams based on a ranking
 func calculateRankings(teams [][]Rating, ranks []int) []int {
 	teamScores := make([]int, len(teams))
@@ -300,25 +371,37 @@ func calculateRankings(teams [][]Rating, ranks []int) []int {
 
 // calculateTeamRatings calculates the ratings of a team of players used for further computations.
 func calculateTeamRatings(teams [][]Rating, ranks []int, balance bool, kappa float64) []teamRating {
+	return calculatePartialTeamRatings(teams, ranks, nil, balance, kappa)
+}
+
+// calculatePartialTeamRatings calculates the ratings of a team of players where each player's contribution
+// is scaled by their share of participation in the match. A nil partialPlay means every player played the full match.
+func calculatePartialTeamRatings(teams [][]Rating, ranks []int, partialPlay [][]float64, balance bool, kappa float64) []teamRating {
 	result := make([]teamRating, len(teams))
 	rank := calculateRankings(teams, ranks)
 
 	for i, team := range teams {
-		sortedTeam := make([]Rating, len(team))
-		copy(sortedTeam, team)
-		sort.SliceStable(sortedTeam, func(i, j int) bool {
-			return sortedTeam[i].Ordinal() > sortedTeam[j].Ordinal()
+		order := make([]int, len(team))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return team[order[a]].Ordinal() > team[order[b]].Ordinal()
 		})
 
-		maxOrdinal := sortedTeam[0].Ordinal()
+		maxOrdinal := team[order[0]].Ordinal()
 		muSummed := 0.0
 		sigmaSqSummed := 0.0
 
-		for _, player := range sortedTeam {
+		for _, j := range order {
+			player := team[j]
 			balanceWeight := 1.0
 			if balance {
 				balanceWeight = 1 + ((maxOrdinal - player.Ordinal()) / (maxOrdinal + kappa))
 			}
+			if partialPlay != nil {
+				balanceWeight *= partialPlay[i][j]
+			}
 			muSummed += player.Mu * balanceWeight
 			sigmaSqSummed += (player.Sigma * balanceWeight) * (player.Sigma * balanceWeight)
 		}
@@ -333,3 +416,60 @@ func calculateTeamRatings(teams [][]Rating, ranks []int, balance bool, kappa flo
 
 	return result
 }
+
+// applyPartialPlay scales the change from each player's rating before the match to after it by their share of participation.
+func applyPartialPlay(before, after [][]Rating, partialPlay [][]float64) [][]Rating {
+	result := make([][]Rating, len(after))
+	for i := range after {
+		result[i] = make([]Rating, len(after[i]))
+		for j, updated := range after[i] {
+			share := partialPlay[i][j]
+			result[i][j] = Rating{
+				Mu:    before[i][j].Mu + share*(updated.Mu-before[i][j].Mu),
+				Sigma: before[i][j].Sigma + share*(updated.Sigma-before[i][j].Sigma),
+			}
+		}
+	}
+
+	return result
+}
+
+// cholesky returns the lower triangular L with L*L' = m, or false if m is not positive definite.
+func cholesky(m [][]float64) ([][]float64, bool) {
+	n := len(m)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := m[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, true
+}
+
+// forwardSubstitution solves l*x = b for a lower triangular l.
+func forwardSubstitution(l [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := range b {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
@@ -1,6 +1,7 @@
 package openskill
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -195,3 +196,87 @@ func TestSumQ(t *testing.T) {
 		assert.InDelta(t, sums[1], 102.421894, delta)
 	})
 }
+
+func TestScoresToRanks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exact", func(t *testing.T) {
+		assert.Equal(t, []int{}, scoresToRanks([]float64{}, tieTolerance{}))
+		assert.Equal(t, []int{1, 2, 3}, scoresToRanks([]float64{10, 5, 3}, tieTolerance{}))
+		assert.Equal(t, []int{3, 1, 2}, scoresToRanks([]float64{-1, 2.5, 2.4}, tieTolerance{}))
+		assert.Equal(t, []int{1, 3, 1}, scoresToRanks([]float64{7, 3, 7}, tieTolerance{}))
+	})
+
+	t.Run("absolute tolerance", func(t *testing.T) {
+		tolerance := tieTolerance{absolute: 0.5}
+
+		assert.Equal(t, []int{3, 1, 1}, scoresToRanks([]float64{-1, 2.5, 2.1}, tolerance))
+		assert.Equal(t, []int{1, 1, 1, 4}, scoresToRanks([]float64{3, 2.6, 2.2, 1}, tolerance))
+	})
+
+	t.Run("relative tolerance", func(t *testing.T) {
+		tolerance := tieTolerance{relative: 0.01}
+
+		assert.Equal(t, []int{1, 1, 3}, scoresToRanks([]float64{1000, 995, 980}, tolerance))
+		assert.Equal(t, []int{1, 2}, scoresToRanks([]float64{1, 0.95}, tolerance))
+	})
+
+	t.Run("larger tolerance applies", func(t *testing.T) {
+		tolerance := tieTolerance{absolute: 0.1, relative: 0.01}
+
+		assert.Equal(t, []int{1, 1}, scoresToRanks([]float64{1, 0.95}, tolerance))
+		assert.Equal(t, []int{1, 1}, scoresToRanks([]float64{1000, 995}, tolerance))
+	})
+}
+
+func TestMarginFactor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1.0, marginFactor(10, 0))
+	assert.Equal(t, 1.0, marginFactor(0, 5))
+	assert.InDelta(t, 1+math.Ln2, marginFactor(5, 5), delta)
+	assert.InDelta(t, 1+math.Ln2, marginFactor(-5, 5), delta)
+	assert.Greater(t, marginFactor(10, 5), marginFactor(5, 5))
+}
+
+func TestMeanMarginFactor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1.0, meanMarginFactor([]float64{3}, 0, 1))
+	assert.InDelta(t, 1+math.Ln2, meanMarginFactor([]float64{2, 1}, 0, 1), delta)
+	assert.InDelta(t, 1+(math.Ln2+math.Log(3))/2, meanMarginFactor([]float64{2, 1, 0}, 0, 1), delta)
+}
+
+func TestCalculatePartialTeamRatings(t *testing.T) {
+	t.Parallel()
+
+	kappa := 0.0001
+	t1 := []Rating{{25, 8}, {30, 4}}
+	t2 := []Rating{{20, 6}}
+
+	t.Run("full participation", func(t *testing.T) {
+		expected := calculateTeamRatings([][]Rating{t1, t2}, nil, true, kappa)
+		actual := calculatePartialTeamRatings([][]Rating{t1, t2}, nil, [][]float64{{1, 1}, {1}}, true, kappa)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("partial participation", func(t *testing.T) {
+		teamRatings := calculatePartialTeamRatings([][]Rating{t1, t2}, nil, [][]float64{{0.5, 1}, {0.25}}, false, kappa)
+
+		assert.InDelta(t, 12.5+30, teamRatings[0].Mu, delta)
+		assert.InDelta(t, 16+16, teamRatings[0].SigmaSquared, delta)
+		assert.InDelta(t, 5, teamRatings[1].Mu, delta)
+		assert.InDelta(t, 2.25, teamRatings[1].SigmaSquared, delta)
+	})
+}
+
+func TestApplyPartialPlay(t *testing.T) {
+	t.Parallel()
+
+	before := [][]Rating{{{25, 8}, {30, 4}}}
+	after := [][]Rating{{{27, 6}, {34, 3}}}
+
+	assert.Equal(t, after, applyPartialPlay(before, after, [][]float64{{1, 1}}))
+	assert.Equal(t, [][]Rating{{{26, 7}, {31, 3.75}}}, applyPartialPlay(before, after, [][]float64{{0.5, 0.25}}))
+}
@@ -0,0 +1,113 @@
+package openskill
+
+import "math"
+
+// WeightStrategy normalizes the weights of the players in a team before they are used to scale their updates.
+// A weight above 1 increases a player's gain when their team does better than expected and reduces their loss
+// when it does worse, while a weight below 1 does the opposite. Normalize must not modify its input.
+type WeightStrategy interface {
+	Normalize(weights []float64) []float64
+}
+
+type minMaxWeights struct {
+	min float64
+	max float64
+}
+
+// MinMaxWeights returns a WeightStrategy that rescales the weights of a team to the range [min, max].
+// A team with a single player always gets max, and a team where all weights are equal always gets min.
+// MinMaxWeights(1, 2) is the default strategy of all models. The model constructors reject min <= 0 and max < min.
+func MinMaxWeights(min, max float64) WeightStrategy {
+	return minMaxWeights{min: min, max: max}
+}
+
+func (m minMaxWeights) Normalize(weights []float64) []float64 {
+	return normalize(weights, m.min, m.max)
+}
+
+type sumToOneWeights struct{}
+
+// SumToOneWeights returns a WeightStrategy that divides the weights of a team by their sum, so they describe each
+// player's share of the team. The shares are multiplied by the size of the team, so players with equal shares get
+// a weight of 1 and are rated as if unweighted. If the weights sum to zero, every player gets an equal share.
+func SumToOneWeights() WeightStrategy {
+	return sumToOneWeights{}
+}
+
+func (sumToOneWeights) Normalize(weights []float64) []float64 {
+	sum := 0.0
+	for _, weight := range weights {
+		sum += weight
+	}
+
+	result := make([]float64, len(weights))
+	for i, weight := range weights {
+		if sum == 0 {
+			result[i] = 1
+		} else {
+			result[i] = weight / sum * float64(len(weights))
+		}
+	}
+	return result
+}
+
+type rawWeights struct{}
+
+// RawWeights returns a WeightStrategy that uses the weights as they are. The weights must be positive.
+func RawWeights() WeightStrategy {
+	return rawWeights{}
+}
+
+func (rawWeights) Normalize(weights []float64) []float64 {
+	return append([]float64{}, weights...)
+}
+
+type softmaxWeights struct{}
+
+// SoftmaxWeights returns a WeightStrategy that applies the softmax function to the weights of a team,
+// so they are positive while preserving differences between them. Like SumToOneWeights, the result is multiplied by
+// the size of the team, so players with equal weights get a weight of 1 and are rated as if unweighted.
+func SoftmaxWeights() WeightStrategy {
+	return softmaxWeights{}
+}
+
+func (softmaxWeights) Normalize(weights []float64) []float64 {
+	if len(weights) == 0 {
+		return []float64{}
+	}
+
+	maxWeight := weights[0]
+	for _, weight := range weights {
+		maxWeight = math.Max(maxWeight, weight)
+	}
+
+	sum := 0.0
+	result := make([]float64, len(weights))
+	for i, weight := range weights {
+		result[i] = math.Exp(weight - maxWeight)
+		sum += result[i]
+	}
+	for i := range result {
+		result[i] = result[i] / sum * float64(len(weights))
+	}
+	return result
+}
+
+// normalizeWeights returns a copy of the weights of every team normalized by the strategy, keeping nil as nil.
+// ErrInvalidWeight is returned if any normalized weight is not finite and positive, since the models divide by them.
+func normalizeWeights(weights [][]float64, strategy WeightStrategy) ([][]float64, error) {
+	if weights == nil {
+		return nil, nil
+	}
+
+	result := make([][]float64, len(weights))
+	for i := range weights {
+		result[i] = strategy.Normalize(weights[i])
+		for _, weight := range result[i] {
+			if !(weight > 0) || math.IsInf(weight, 1) {
+				return nil, ErrInvalidWeight
+			}
+		}
+	}
+	return result, nil
+}
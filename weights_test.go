@@ -0,0 +1,80 @@
+package openskill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxWeights(t *testing.T) {
+	t.Parallel()
+
+	s := MinMaxWeights(1, 2)
+
+	assert.Equal(t, []float64{1, 1.5, 2}, s.Normalize([]float64{1, 2, 3}))
+	assert.Equal(t, []float64{1, 1}, s.Normalize([]float64{0.5, 0.5}))
+	assert.Equal(t, []float64{2}, s.Normalize([]float64{0.1}))
+}
+
+func TestSumToOneWeights(t *testing.T) {
+	t.Parallel()
+
+	s := SumToOneWeights()
+
+	assert.Equal(t, []float64{0.5, 1.5}, s.Normalize([]float64{1, 3}))
+	assert.Equal(t, []float64{1, 1}, s.Normalize([]float64{0.5, 0.5}))
+	assert.Equal(t, []float64{1, 1}, s.Normalize([]float64{0, 0}))
+	assert.Equal(t, []float64{1}, s.Normalize([]float64{0.1}))
+	assert.Equal(t, []float64{0.5, 1, 1.5}, s.Normalize([]float64{1, 2, 3}))
+}
+
+func TestRawWeights(t *testing.T) {
+	t.Parallel()
+
+	weights := []float64{0.5, 3}
+	normalized := RawWeights().Normalize(weights)
+	normalized[0] = 1
+
+	assert.Equal(t, []float64{0.5, 3}, weights)
+}
+
+func TestSoftmaxWeights(t *testing.T) {
+	t.Parallel()
+
+	s := SoftmaxWeights()
+
+	assert.Equal(t, []float64{}, s.Normalize([]float64{}))
+	assert.Equal(t, []float64{1, 1}, s.Normalize([]float64{3, 3}))
+	assert.Equal(t, []float64{1}, s.Normalize([]float64{1000}))
+
+	normalized := s.Normalize([]float64{1, 2, 3})
+	assert.InDelta(t, 3, normalized[0]+normalized[1]+normalized[2], delta)
+	assert.Less(t, normalized[0], normalized[1])
+	assert.Less(t, normalized[1], normalized[2])
+
+	assert.InDelta(t, 2, s.Normalize([]float64{1, 1000})[1], delta)
+}
+
+func TestNormalizeWeights(t *testing.T) {
+	t.Parallel()
+
+	normalized, err := normalizeWeights(nil, MinMaxWeights(1, 2))
+	assert.NoError(t, err)
+	assert.Nil(t, normalized)
+
+	weights := [][]float64{{1, 2, 3}, {1}}
+	normalized, err = normalizeWeights(weights, MinMaxWeights(1, 2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{1, 1.5, 2}, {2}}, normalized)
+	assert.Equal(t, [][]float64{{1, 2, 3}, {1}}, weights)
+
+	_, err = normalizeWeights([][]float64{{1, 0}, {1}}, RawWeights())
+	assert.ErrorIs(t, err, ErrInvalidWeight)
+
+	_, err = normalizeWeights([][]float64{{1, -1}, {1}}, RawWeights())
+	assert.ErrorIs(t, err, ErrInvalidWeight)
+
+	_, err = normalizeWeights([][]float64{{1, 0}, {1}}, MinMaxWeights(1, 2))
+	assert.NoError(t, err)
+}